@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/doppler"
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+	"github.com/Mohammed-Ashour/tlego/pkg/passes"
+	"github.com/urfave/cli/v3"
+)
+
+// dopplerSearch is how far ahead doppler looks for the next pass.
+const dopplerSearch = 48 * time.Hour
+
+func init() {
+	RootCmd.Commands = append(RootCmd.Commands, &cli.Command{
+		Name:        "doppler",
+		Usage:       "tlego doppler <NORAD-ID> --lat <deg> --lon <deg> --downlink <freq> [--uplink <freq>] [--time <timestamp>] [--duration <d>]",
+		Description: "Compute Doppler-corrected downlink and uplink frequencies over the next pass, or over --duration from --time. Frequencies take an Hz, kHz, MHz or GHz suffix; bare numbers are MHz.",
+		Action:      dopplerSatellite,
+		Category:    "Prediction",
+		Flags: append(observerFlags(true),
+			&cli.StringFlag{Name: "downlink", Usage: "frequency the satellite transmits on, e.g. 437.8MHz"},
+			&cli.StringFlag{Name: "uplink", Usage: "frequency the satellite listens on, e.g. 145.99MHz"},
+			&cli.StringFlag{Name: "time", Usage: "start of the series in ISO 8601 format (default the next pass)"},
+			&cli.DurationFlag{Name: "duration", Usage: "length of the series, e.g. 15m (default the next pass)"},
+			&cli.DurationFlag{Name: "step", Usage: "time between rows", Value: 10 * time.Second},
+			&cli.StringFlag{Name: "format", Usage: "output format: table, json or csv", Value: "table"},
+		),
+	})
+}
+
+// frequencyFlags reads the link frequencies, requiring at least one.
+func frequencyFlags(cmd *cli.Command) (doppler.Frequencies, error) {
+	var f doppler.Frequencies
+	var err error
+	if cmd.IsSet("downlink") {
+		if f.Downlink, err = doppler.ParseFrequency(cmd.String("downlink")); err != nil {
+			return f, fmt.Errorf("invalid --downlink: %w", err)
+		}
+	}
+	if cmd.IsSet("uplink") {
+		if f.Uplink, err = doppler.ParseFrequency(cmd.String("uplink")); err != nil {
+			return f, fmt.Errorf("invalid --uplink: %w", err)
+		}
+	}
+	if f.Downlink == 0 && f.Uplink == 0 {
+		return f, errors.New("please provide a --downlink or --uplink frequency")
+	}
+	return f, nil
+}
+
+func dopplerSatellite(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() == 0 {
+		return errors.New("please provide a NORAD ID for the satellite to correct frequencies for")
+	}
+	noradID, err := parseNoradID(args.First())
+	if err != nil {
+		return err
+	}
+	site, err := observerFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	freqs, err := frequencyFlags(cmd)
+	if err != nil {
+		return err
+	}
+	start, err := timeFlag(cmd, "time")
+	if err != nil {
+		return err
+	}
+	duration := cmd.Duration("duration")
+	if duration < 0 {
+		return fmt.Errorf("--duration must not be negative, got %v", duration)
+	}
+
+	src, err := openSource()
+	if err != nil {
+		return err
+	}
+	tle, err := src.GetSatelliteTLEByNoradID(ctx, noradID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch TLE for NORAD ID %s: %w", noradID, err)
+	}
+	tle = elementSetAt(ctx, src, tle, start)
+
+	prop, err := observer.NewSGP4(tle)
+	if err != nil {
+		return err
+	}
+	end := start.Add(duration)
+	if !cmd.IsSet("duration") {
+		// follow the pass in progress at start, or the next one
+		found, err := passes.Find(prop, site, start, start.Add(dopplerSearch), passes.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to predict passes for NORAD ID %s: %w", noradID, err)
+		}
+		if len(found) == 0 {
+			return fmt.Errorf("no pass of NORAD ID %s in the next %v, use --duration to pick a span", noradID, dopplerSearch)
+		}
+		start, end = found[0].AOS, found[0].LOS
+	}
+	looks, err := observer.Track(prop, site, start, end, cmd.Duration("step"))
+	if err != nil {
+		return fmt.Errorf("failed to compute look angles for NORAD ID %s: %w", noradID, err)
+	}
+	samples := doppler.Series(freqs, looks)
+
+	format := strings.ToLower(cmd.String("format"))
+	if format == "table" {
+		fmt.Printf("Doppler for %s (NORAD ID: %s) over %.4f, %.4f from %s to %s\n",
+			tle.Name, noradID, site.Latitude, site.Longitude, start.Format(time.RFC3339), end.Format(time.RFC3339))
+		if freqs.Downlink != 0 {
+			fmt.Printf("Downlink: %.6f MHz\n", freqs.Downlink/1e6)
+		}
+		if freqs.Uplink != 0 {
+			fmt.Printf("Uplink: %.6f MHz\n", freqs.Uplink/1e6)
+		}
+		fmt.Println()
+	}
+	return doppler.Write(os.Stdout, format, freqs, samples)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/eclipse"
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+	"github.com/urfave/cli/v3"
+)
+
+func init() {
+	RootCmd.Commands = append(RootCmd.Commands, &cli.Command{
+		Name:        "eclipse",
+		Usage:       "tlego eclipse <NORAD-ID> [--days <n>] [--start <timestamp>] [--beta [--beta-step <d>]]",
+		Description: "Find when a satellite passes through the Earth's shadow: penumbra and umbra entry and exit times, durations, the share of the orbit in shadow and the solar beta angle. --beta prints the beta angle as a time series instead.",
+		Action:      findEclipses,
+		Category:    "Prediction",
+		Flags: []cli.Flag{
+			&cli.FloatFlag{Name: "days", Usage: "number of days to search", Value: 1},
+			&cli.StringFlag{Name: "start", Usage: "start of the search in ISO 8601 format (default now)"},
+			&cli.BoolFlag{Name: "beta", Usage: "print the solar beta angle time series instead of eclipses"},
+			&cli.DurationFlag{Name: "beta-step", Usage: "time between beta angle samples", Value: time.Hour},
+			&cli.StringFlag{Name: "format", Usage: "output format: table, json or csv", Value: "table"},
+		},
+	})
+}
+
+func findEclipses(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() == 0 {
+		return errors.New("please provide a NORAD ID for the satellite to find eclipses of")
+	}
+	noradID, err := parseNoradID(args.First())
+	if err != nil {
+		return err
+	}
+	start, err := timeFlag(cmd, "start")
+	if err != nil {
+		return err
+	}
+	days := cmd.Float("days")
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive, got %v", days)
+	}
+	end := start.Add(time.Duration(days * float64(24*time.Hour)))
+
+	src, err := openSource()
+	if err != nil {
+		return err
+	}
+	tle, err := src.GetSatelliteTLEByNoradID(ctx, noradID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch TLE for NORAD ID %s: %w", noradID, err)
+	}
+	tle = elementSetAt(ctx, src, tle, start)
+
+	prop, err := observer.NewSGP4(tle)
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(cmd.String("format"))
+	if format == "table" {
+		fmt.Printf("Eclipses of %s (NORAD ID: %s) from %s to %s\n",
+			tle.Name, noradID, start.Format(time.RFC3339), end.Format(time.RFC3339))
+		fmt.Printf("TLE Epoch: %s\n", tle.Elements.Epoch.Format(time.RFC3339))
+	}
+
+	plane := eclipse.NewMeanPlane(tle.Elements)
+	if cmd.Bool("beta") {
+		samples, err := eclipse.BetaSeries(plane, start, end, cmd.Duration("beta-step"))
+		if err != nil {
+			return fmt.Errorf("failed to compute beta angles for NORAD ID %s: %w", noradID, err)
+		}
+		if format == "table" {
+			fmt.Println()
+		}
+		return eclipse.WriteBeta(os.Stdout, format, samples)
+	}
+
+	var period time.Duration
+	if tle.Elements.MeanMotion > 0 {
+		period = time.Duration(float64(24*time.Hour) / tle.Elements.MeanMotion)
+	}
+	found, err := eclipse.Find(prop, start, end, eclipse.Options{Period: period, Plane: &plane})
+	if err != nil {
+		return fmt.Errorf("failed to find eclipses for NORAD ID %s: %w", noradID, err)
+	}
+	if format == "table" {
+		var shadow time.Duration
+		for _, e := range found {
+			shadow += e.Duration
+		}
+		fmt.Printf("Orbital Period: %s\n", period.Round(time.Second))
+		fmt.Printf("Time in Shadow: %s (%.1f%%)\n\n", shadow.Round(time.Second), 100*shadow.Seconds()/end.Sub(start).Seconds())
+		if len(found) == 0 {
+			fmt.Println("No eclipses found")
+			return nil
+		}
+	}
+	return eclipse.Write(os.Stdout, format, found)
+}
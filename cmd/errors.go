@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
+	"github.com/Mohammed-Ashour/tlego/pkg/source"
+	"github.com/urfave/cli/v3"
+)
+
+// Exit codes, so scripts can tell why tlego failed.
+const (
+	exitError       = 1 // anything not listed below
+	exitNotFound    = 3 // no element set matched the query
+	exitRateLimited = 4 // CelesTrak refused the request for downloading too often
+	exitServerError = 5 // CelesTrak answered with a 5xx status
+	exitMalformed   = 6 // the response could not be decoded
+	exitNotCached   = 7 // --offline and the query has no cached response
+)
+
+// exitCode maps an error returned by a command to the process exit code.
+func exitCode(err error) int {
+	var exitErr cli.ExitCoder
+	switch {
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode()
+	case source.IsNotFound(err):
+		return exitNotFound
+	case errors.Is(err, celestrak.ErrRateLimited):
+		return exitRateLimited
+	case errors.Is(err, celestrak.ErrServer):
+		return exitServerError
+	case errors.Is(err, celestrak.ErrMalformed):
+		return exitMalformed
+	case errors.Is(err, celestrak.ErrNotCached):
+		return exitNotCached
+	default:
+		return exitError
+	}
+}
+
+// handleExitError prints the error a command failed with and exits with the
+// matching exit code.
+func handleExitError(ctx context.Context, cmd *cli.Command, err error) {
+	if err == nil {
+		return
+	}
+	if msg := err.Error(); msg != "" {
+		fmt.Fprintln(cli.ErrWriter, "Error:", msg)
+	}
+	cli.OsExiter(exitCode(err))
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
-	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
+	"github.com/Mohammed-Ashour/tlego/pkg/source"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
 	"github.com/urfave/cli/v3"
 )
 
@@ -20,18 +20,6 @@ func init() {
 				Name:     "sat-group",
 				Usage:    "tlego list --sat-group <sat-group>",
 				Category: "TLE",
-				Validator: func(g string) error {
-					config, err := celestrak.ReadCelestrakConfig()
-					if err != nil {
-						return fmt.Errorf("failed to read Celestrak configuration: %w", err)
-					}
-					for _, group := range config.SatelliteGroups {
-						if strings.EqualFold(g, group.Name) {
-							return nil
-						}
-					}
-					return fmt.Errorf("invalid satellite group: %s. Use 'tlego list' to see available groups", g)
-				},
 			},
 		},
 		Action: listAction,
@@ -40,30 +28,46 @@ func init() {
 }
 
 func listAction(ctx context.Context, cmd *cli.Command) error {
-	config, err := celestrak.ReadCelestrakConfig()
+	src, err := openSource()
 	if err != nil {
 		return err
 	}
 	groupFlag := cmd.String("sat-group")
 	if groupFlag != "" {
-		tles, err := celestrak.GetSatelliteGroupTLEs(groupFlag, config)
+		tles, err := src.GetGroupTLEs(ctx, groupFlag)
+		if source.IsNotFound(err) {
+			return fmt.Errorf("invalid satellite group: %s. Use 'tlego list' to see available groups: %w", groupFlag, err)
+		}
 		for _, tle := range tles {
 			fmt.Println(tle)
 		}
 		return err
 	}
+
+	lister, ok := src.(source.GroupLister)
+	if !ok {
+		return fmt.Errorf("the selected source has no groups")
+	}
+	groups, err := lister.Groups(ctx)
+	if err != nil {
+		return err
+	}
 	fmt.Println("Groups supported : ")
-	for _, satGroup := range config.SatelliteGroups {
-		fmt.Printf("\t%s\n", satGroup.Name)
+	for _, name := range groups {
+		fmt.Printf("\t%s\n", name)
 	}
 	return fmt.Errorf("no sat-group was provided: --sat-group=%s", groupFlag)
 }
-func validateNoradID(noradId string) error {
+
+// parseNoradID validates a NORAD ID given in numeric or Alpha-5 form (e.g. A0001)
+// and returns it in the numeric form CelesTrak expects.
+func parseNoradID(noradId string) (string, error) {
 	if noradId == "" {
-		return fmt.Errorf("NORAD ID cannot be empty")
+		return "", fmt.Errorf("NORAD ID cannot be empty")
 	}
-	if _, err := strconv.Atoi(noradId); err != nil {
-		return fmt.Errorf("NORAD ID must be numeric: %s", noradId)
+	catalogNumber, err := tle.ParseCatalogNumber(noradId)
+	if err != nil {
+		return "", fmt.Errorf("NORAD ID must be numeric or Alpha-5: %s", noradId)
 	}
-	return nil
+	return strconv.Itoa(catalogNumber), nil
 }
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+	"github.com/Mohammed-Ashour/tlego/pkg/sun"
+	"github.com/urfave/cli/v3"
+)
+
+func init() {
+	RootCmd.Commands = append(RootCmd.Commands, &cli.Command{
+		Name:        "look",
+		Usage:       "tlego look <NORAD-ID> --lat <deg> --lon <deg> [--time <timestamp>] [--duration <d> --step <d>]",
+		Description: "Show where a satellite appears to a ground station: azimuth, elevation, slant range and range rate, at one instant or as a table over a time span.",
+		Action:      lookAtSatellite,
+		Category:    "Prediction",
+		Flags: append(observerFlags(true),
+			&cli.StringFlag{Name: "time", Usage: "instant, or start of the table, in ISO 8601 format (default now)"},
+			&cli.DurationFlag{Name: "duration", Usage: "print a table over this span, e.g. 15m"},
+			&cli.DurationFlag{Name: "step", Usage: "time between table rows", Value: 10 * time.Second},
+			&cli.StringFlag{Name: "format", Usage: "output format: table, json or csv", Value: "table"},
+		),
+	})
+}
+
+func lookAtSatellite(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() == 0 {
+		return errors.New("please provide a NORAD ID for the satellite to look at")
+	}
+	noradID, err := parseNoradID(args.First())
+	if err != nil {
+		return err
+	}
+	site, err := observerFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	at, err := timeFlag(cmd, "time")
+	if err != nil {
+		return err
+	}
+	duration := cmd.Duration("duration")
+	if duration < 0 {
+		return fmt.Errorf("--duration must not be negative, got %v", duration)
+	}
+
+	src, err := openSource()
+	if err != nil {
+		return err
+	}
+	tle, err := src.GetSatelliteTLEByNoradID(ctx, noradID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch TLE for NORAD ID %s: %w", noradID, err)
+	}
+	tle = elementSetAt(ctx, src, tle, at)
+
+	prop, err := observer.NewSGP4(tle)
+	if err != nil {
+		return err
+	}
+	samples, err := observer.Track(prop, site, at, at.Add(duration), cmd.Duration("step"))
+	if err != nil {
+		return fmt.Errorf("failed to compute look angles for NORAD ID %s: %w", noradID, err)
+	}
+
+	format := strings.ToLower(cmd.String("format"))
+	if format != "table" {
+		return observer.Write(os.Stdout, format, samples)
+	}
+	fmt.Printf("Satellite: %s (NORAD ID: %s)\n", tle.Name, noradID)
+	fmt.Printf("Observer: Latitude %.6f, Longitude %.6f, Altitude %.1f m\n", site.Latitude, site.Longitude, site.Altitude)
+	fmt.Printf("TLE Epoch: %s\n", tle.Elements.Epoch.Format(time.RFC3339))
+	if duration == 0 {
+		look := samples[0]
+		fmt.Printf("Time: %s\n", look.Time.Format(time.RFC3339))
+		fmt.Printf("Azimuth: %.4f°\n", look.Azimuth)
+		fmt.Printf("Elevation: %.4f°\n", look.Elevation)
+		fmt.Printf("Range: %.3f km\n", look.Range)
+		fmt.Printf("Range Rate: %.4f km/s\n", look.RangeRate)
+		shadow, err := sun.ShadowAt(prop, look.Time)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Illumination: %s\n", shadow)
+		fmt.Printf("Sun Elevation: %.2f°\n", sun.Elevation(site, look.Time))
+		return nil
+	}
+	fmt.Println()
+	return observer.Write(os.Stdout, "table", samples)
+}
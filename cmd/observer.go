@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+	"github.com/urfave/cli/v3"
+)
+
+// observerFlags are the flags locating the ground station. Commands that only
+// sometimes need one pass required=false and check hasObserver themselves.
+func observerFlags(required bool) []cli.Flag {
+	return []cli.Flag{
+		&cli.FloatFlag{Name: "lat", Usage: "observer latitude in degrees, north positive", Required: required},
+		&cli.FloatFlag{Name: "lon", Usage: "observer longitude in degrees, east positive", Required: required},
+		&cli.FloatFlag{Name: "alt", Usage: "observer altitude in meters above the WGS84 ellipsoid"},
+	}
+}
+
+// hasObserver reports whether the ground station was given.
+func hasObserver(cmd *cli.Command) bool {
+	return cmd.IsSet("lat") && cmd.IsSet("lon")
+}
+
+// observerFromFlags reads the observer from observerFlags.
+func observerFromFlags(cmd *cli.Command) (observer.Observer, error) {
+	o := observer.Observer{
+		Latitude:  cmd.Float("lat"),
+		Longitude: cmd.Float("lon"),
+		Altitude:  cmd.Float("alt"),
+	}
+	if err := o.Validate(); err != nil {
+		return observer.Observer{}, fmt.Errorf("invalid observer: %w", err)
+	}
+	return o, nil
+}
+
+// timeFlag parses an optional time flag, defaulting to now.
+func timeFlag(cmd *cli.Command, name string) (time.Time, error) {
+	if !cmd.IsSet(name) {
+		return time.Now().UTC(), nil
+	}
+	t, err := parseTime(cmd.String(name))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s: %w", name, err)
+	}
+	return t, nil
+}
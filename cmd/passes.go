@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+	"github.com/Mohammed-Ashour/tlego/pkg/passes"
+	"github.com/urfave/cli/v3"
+)
+
+func init() {
+	RootCmd.Commands = append(RootCmd.Commands, &cli.Command{
+		Name:        "passes",
+		Usage:       "tlego passes <NORAD-ID> --lat <deg> --lon <deg> [--alt <m>] [--days <n>] [--visible] [--magnitude <mag>]",
+		Description: "Predict when a satellite will be above a ground station: rise (AOS), culmination (TCA) and set (LOS) times, azimuths, maximum elevation and duration, and with --visible, --twilight or --magnitude when it is visible by eye: sunlit against a dark sky.",
+		Action:      predictPasses,
+		Category:    "Prediction",
+		Flags: append(observerFlags(true),
+			&cli.FloatFlag{Name: "days", Usage: "number of days to search", Value: 1},
+			&cli.StringFlag{Name: "start", Usage: "start of the search in ISO 8601 format (default now)"},
+			&cli.FloatFlag{Name: "min-elevation", Usage: "minimum elevation in degrees a pass must reach above the horizon"},
+			&cli.BoolFlag{Name: "visible", Usage: "list only passes visible by eye"},
+			&cli.FloatFlag{Name: "twilight", Usage: "solar elevation in degrees the sky must be darker than to see the satellite", Value: passes.DefaultTwilight},
+			&cli.FloatFlag{Name: "magnitude", Usage: "standard visual magnitude of the satellite (at 1000 km, 90° phase) to estimate its brightness"},
+			&cli.StringFlag{Name: "format", Usage: "output format: table, json or csv", Value: "table"},
+		),
+	})
+}
+
+func predictPasses(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() == 0 {
+		return errors.New("please provide a NORAD ID for the satellite to predict passes")
+	}
+	noradID, err := parseNoradID(args.First())
+	if err != nil {
+		return err
+	}
+	site, err := observerFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	start, err := timeFlag(cmd, "start")
+	if err != nil {
+		return err
+	}
+	days := cmd.Float("days")
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive, got %v", days)
+	}
+	end := start.Add(time.Duration(days * float64(24*time.Hour)))
+
+	src, err := openSource()
+	if err != nil {
+		return err
+	}
+	tle, err := src.GetSatelliteTLEByNoradID(ctx, noradID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch TLE for NORAD ID %s: %w", noradID, err)
+	}
+	tle = elementSetAt(ctx, src, tle, start)
+
+	prop, err := observer.NewSGP4(tle)
+	if err != nil {
+		return err
+	}
+	opts := passes.Options{
+		MinElevation: cmd.Float("min-elevation"),
+		VisibleOnly:  cmd.Bool("visible"),
+	}
+	if cmd.IsSet("twilight") {
+		twilight := cmd.Float("twilight")
+		opts.Twilight = &twilight
+	}
+	if cmd.IsSet("magnitude") {
+		standard := cmd.Float("magnitude")
+		opts.StandardMagnitude = &standard
+	}
+	found, err := passes.Find(prop, site, start, end, opts)
+	if err != nil {
+		return fmt.Errorf("failed to predict passes for NORAD ID %s: %w", noradID, err)
+	}
+
+	format := strings.ToLower(cmd.String("format"))
+	if format == "table" {
+		fmt.Printf("Passes of %s (NORAD ID: %s) over %.4f, %.4f from %s to %s\n",
+			tle.Name, noradID, site.Latitude, site.Longitude, start.Format(time.RFC3339), end.Format(time.RFC3339))
+		fmt.Printf("TLE Epoch: %s\n\n", tle.Elements.Epoch.Format(time.RFC3339))
+		if len(found) == 0 && opts.VisibleOnly {
+			fmt.Println("No visible passes found")
+			return nil
+		}
+		if len(found) == 0 {
+			fmt.Println("No passes found")
+			return nil
+		}
+	}
+	return passes.Write(os.Stdout, format, found)
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/satellite"
-	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
+	"github.com/Mohammed-Ashour/tlego/pkg/archive"
 	"github.com/Mohammed-Ashour/tlego/pkg/locate"
+	"github.com/Mohammed-Ashour/tlego/pkg/logger"
+	"github.com/Mohammed-Ashour/tlego/pkg/source"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
 	"github.com/urfave/cli/v3"
 )
 
@@ -16,7 +20,7 @@ func init() {
 	RootCmd.Commands = append(RootCmd.Commands, &cli.Command{
 		Name:        "predict",
 		Usage:       "tlego predict <NORAD-ID> --time <timestamp>",
-		Description: "Show where a satellite will be at a specific time.",
+		Description: "Show where a satellite will be at a specific time, using the fetched or archived element set with the epoch closest to it.",
 		Action:      predictSatellitePosition,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -35,8 +39,7 @@ func predictSatellitePosition(ctx context.Context, cmd *cli.Command) error {
 		return errors.New("please provide a NORAD ID for the satellite to predict its position")
 	}
 
-	noradID := args.First()
-	err := validateNoradID(noradID)
+	noradID, err := parseNoradID(args.First())
 	if err != nil {
 		return err
 	}
@@ -49,13 +52,18 @@ func predictSatellitePosition(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	// Fetch TLE data for the satellite
-	tle, err := celestrak.GetSatelliteTLEByNoradID(noradID)
+	src, err := openSource()
+	if err != nil {
+		return err
+	}
+	tle, err := src.GetSatelliteTLEByNoradID(ctx, noradID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch TLE for NORAD ID %s: %w", noradID, err)
 	}
+	tle = elementSetAt(ctx, src, tle, predictionTime)
 
 	// Create a satellite object from the TLE
-	satellite := satellite.NewSatelliteFromTLE(tle, satellite.GravityWGS84)
+	satellite := satellite.TLEToSat(tle.Line1.LineString, tle.Line2.LineString, satellite.GravityWGS84)
 
 	// Calculate the satellite's position at the specified time
 
@@ -64,6 +72,7 @@ func predictSatellitePosition(ctx context.Context, cmd *cli.Command) error {
 	// Display the results
 	fmt.Printf("Satellite: %s (NORAD ID: %s)\n", tle.Name, noradID)
 	fmt.Printf("Prediction Time: %s\n", predictionTime.Format(time.RFC3339))
+	fmt.Printf("TLE Epoch: %s\n", tle.Elements.Epoch.Format(time.RFC3339))
 	fmt.Printf("Satellite Position: Latitude %.6f, Longitude %.6f, Altitude %.6f\n", lat, lon, alt)
 
 	// Generate Google Maps URL
@@ -73,6 +82,32 @@ func predictSatellitePosition(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+// historyWindow is how far from the requested time a HistorySource is
+// searched when the current element set is older or newer than that.
+const historyWindow = 3 * 24 * time.Hour
+
+// elementSetAt picks the element set with the epoch closest to t among the
+// current one and the archived ones. Sources with history are asked for the
+// element sets around t first, which also archives them.
+func elementSetAt(ctx context.Context, src source.Source, current tle.TLE, t time.Time) tle.TLE {
+	noradID := strconv.Itoa(current.Elements.CatalogNumber)
+	candidates := []tle.TLE{current}
+
+	if hs, ok := src.(source.HistorySource); ok && archive.EpochDistance(current, t) > historyWindow {
+		history, err := hs.GetTLEHistory(ctx, noradID, t.Add(-historyWindow), t.Add(historyWindow))
+		if err != nil {
+			logger.Warn("Failed to fetch TLE history", "norad_id", noradID, "error", err)
+		}
+		candidates = append(candidates, history...)
+	}
+	if a := archive.Default(); a != nil {
+		if archived, err := a.Closest(noradID, t); err == nil {
+			candidates = append(candidates, archived)
+		}
+	}
+	return archive.Closest(candidates, t)
+}
+
 // parseTime parses the --time flag into a time.Time object
 func parseTime(timeStr string) (time.Time, error) {
 	parsedTime, err := time.Parse(time.RFC3339, timeStr)
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/satellite"
-	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/tle"
-	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
 	"github.com/Mohammed-Ashour/tlego/pkg/locate"
+	"github.com/Mohammed-Ashour/tlego/pkg/logger"
+	"github.com/Mohammed-Ashour/tlego/pkg/satcat"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
 	"github.com/urfave/cli/v3"
 )
 
@@ -29,20 +31,28 @@ func generateSatelliteReport(ctx context.Context, cmd *cli.Command) error {
 		return errors.New("please provide a NORAD ID for the satellite to generate a report")
 	}
 
-	noradID := args.First()
-	err := validateNoradID(noradID)
+	noradID, err := parseNoradID(args.First())
 	if err != nil {
 		return err
 	}
 
 	// Fetch TLE data for the satellite
-	tle, err := celestrak.GetSatelliteTLEByNoradID(noradID)
+	src, err := openSource()
+	if err != nil {
+		return err
+	}
+	tle, err := src.GetSatelliteTLEByNoradID(ctx, noradID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch TLE for NORAD ID %s: %w", noradID, err)
 	}
 
+	params, err := tle.OrbitParameters()
+	if err != nil {
+		return fmt.Errorf("failed to derive orbit parameters for NORAD ID %s: %w", noradID, err)
+	}
+
 	// Create a satellite object from the TLE
-	sat := satellite.NewSatelliteFromTLE(tle, satellite.GravityWGS84)
+	sat := satellite.TLEToSat(tle.Line1.LineString, tle.Line2.LineString, satellite.GravityWGS84)
 
 	// Calculate the satellite's current position
 	now := time.Now()
@@ -50,8 +60,16 @@ func generateSatelliteReport(ctx context.Context, cmd *cli.Command) error {
 	fmt.Println(alt)
 	fmt.Println("------")
 
+	// Catalog metadata is a bonus: the report is still useful without it
+	var record *satcat.Record
+	if cat, err := loadSATCAT(ctx); err != nil {
+		logger.Warn("Reporting without SATCAT data", "error", err)
+	} else if rec, ok := cat.Lookup(tle.Elements.CatalogNumber); ok {
+		record = &rec
+	}
+
 	// Generate the report
-	report := generateReport(tle, lat, lon, alt, now)
+	report := generateReport(tle, params, record, lat, lon, alt, now)
 
 	// Display the report
 	fmt.Println(report)
@@ -59,7 +77,7 @@ func generateSatelliteReport(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-func generateReport(tle tle.TLE, lat, lon, alt float64, now time.Time) string {
+func generateReport(tle tle.TLE, params tle.OrbitParameters, record *satcat.Record, lat, lon, alt float64, now time.Time) string {
 	// Format the report
 
 	report := fmt.Sprintf(`
@@ -71,7 +89,18 @@ NORAD ID: %s
 TLE Data:
 ---------
 %s
-
+%s
+Orbital Parameters:
+-------------------
+Inclination: %.6f° (degrees)
+Eccentricity: %.7f
+Mean Motion: %.8f (revolutions per day)
+Semi-Major Axis: %.3f km
+Period: %.3f minutes
+Apogee Altitude: %.3f km
+Perigee Altitude: %.3f km
+Specific Energy: %.6f km²/s²
+Orbit Regime: %s
 
 Current Position (as of %s):
 ----------------------------
@@ -86,6 +115,16 @@ Google Maps URL:
 		tle.Name,
 		tle.NoradID,
 		tle.String(),
+		catalogSection(record),
+		tle.Elements.Inclination,
+		tle.Elements.Eccentricity,
+		tle.Elements.MeanMotion,
+		params.SemiMajorAxis,
+		params.Period,
+		params.ApogeeAltitude,
+		params.PerigeeAltitude,
+		params.SpecificEnergy,
+		params.Regime,
 		now.Format(time.RFC3339),
 		lat,
 		lon,
@@ -96,3 +135,41 @@ Google Maps URL:
 
 	return report
 }
+
+// catalogSection formats the SATCAT record of the report, if there is one.
+func catalogSection(rec *satcat.Record) string {
+	if rec == nil {
+		return ""
+	}
+	date := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Format(time.DateOnly)
+	}
+	rcs := "unknown"
+	if !math.IsNaN(rec.RCS) {
+		rcs = fmt.Sprintf("%.4f m² (%s)", rec.RCS, rec.RCSSize())
+	}
+	return fmt.Sprintf(`
+Catalog Information:
+--------------------
+International Designator: %s
+Object Type: %s
+Owner: %s
+Operational Status: %s
+Launch Date: %s
+Launch Site: %s
+Decay Date: %s
+Radar Cross Section: %s
+`,
+		rec.ObjectID,
+		rec.TypeName(),
+		rec.Owner,
+		rec.StatusName(),
+		date(rec.LaunchDate),
+		rec.LaunchSite,
+		date(rec.DecayDate),
+		rcs,
+	)
+}
@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"path/filepath"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/archive"
+	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
 	"github.com/urfave/cli/v3"
 )
 
@@ -23,4 +28,34 @@ var RootCmd = &cli.Command{
 			Website: "blog.m-ashour.space",
 		},
 	},
+	ExitErrHandler: handleExitError,
+	Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		// keep every fetched element set in an archive next to the downloads
+		archive.ARCHIVE_DIR = filepath.Join(celestrak.DOWNLOAD_DIR, "archive")
+		return ctx, nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "source",
+			Usage:       "where element sets come from: celestrak, spacetrack, - (stdin), or a TLE/OMM file or directory",
+			Value:       "celestrak",
+			Destination: &sourceSpec,
+		},
+		&cli.StringFlag{
+			Name:        "config",
+			Usage:       "satellite groups file layered over the built-in groups (default: $XDG_CONFIG_HOME/tlego/satellite_groups.yaml)",
+			Destination: &celestrak.CONFIG_FILE,
+		},
+		&cli.BoolFlag{
+			Name:        "offline",
+			Usage:       "serve TLEs only from the local cache, never contacting CelesTrak",
+			Destination: &celestrak.OFFLINE,
+		},
+		&cli.DurationFlag{
+			Name:        "cache-max-age",
+			Usage:       "how long cached TLEs are used before CelesTrak is asked again (e.g. 30m, 6h)",
+			Value:       celestrak.CACHE_MAX_AGE,
+			Destination: &celestrak.CACHE_MAX_AGE,
+		},
+	},
 }
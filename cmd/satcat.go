@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
+	"github.com/Mohammed-Ashour/tlego/pkg/satcat"
+)
+
+// loadSATCAT fetches the CelesTrak satellite catalog, whatever --source is:
+// none of the other sources publish one.
+func loadSATCAT(ctx context.Context) (*satcat.Catalog, error) {
+	return celestrak.NewClient().GetSATCAT(ctx)
+}
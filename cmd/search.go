@@ -3,64 +3,154 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
-	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
+	"github.com/Mohammed-Ashour/tlego/pkg/logger"
+	"github.com/Mohammed-Ashour/tlego/pkg/satcat"
+	"github.com/Mohammed-Ashour/tlego/pkg/search"
+	"github.com/Mohammed-Ashour/tlego/pkg/source"
 	"github.com/urfave/cli/v3"
 )
 
+// rangeFlags are the search flags taking a MIN:MAX range, in the order they
+// are listed in the help.
+var rangeFlags = []struct{ name, usage string }{
+	{"norad", "NORAD ID range, e.g. 25000:26000"},
+	{"inclination", "inclination range in degrees, e.g. 97:99"},
+	{"period", "orbital period range in minutes, e.g. :100"},
+	{"apogee", "apogee altitude range in km, e.g. 35000:"},
+	{"perigee", "perigee altitude range in km, e.g. 500:600"},
+}
+
 func init() {
+	flags := []cli.Flag{
+		&cli.StringFlag{Name: "intdes", Usage: "international designator prefix, e.g. 1998-067 or 2024-"},
+		&cli.IntFlag{Name: "launch-year", Usage: "launch year from the international designator"},
+		&cli.StringFlag{Name: "group", Usage: "only satellites in this group"},
+		&cli.StringFlag{Name: "type", Usage: "SATCAT object types, comma-separated: payload, rocket-body, debris, unknown"},
+		&cli.StringFlag{Name: "country", Usage: "SATCAT owner or country codes, comma-separated, e.g. US,PRC"},
+		&cli.StringFlag{Name: "status", Usage: "SATCAT status: operational, nonoperational, decayed, in-orbit or a status code"},
+	}
+	for _, f := range rangeFlags {
+		flags = append(flags, &cli.StringFlag{Name: f.name, Usage: f.usage})
+	}
+	flags = append(flags,
+		&cli.StringFlag{Name: "sort", Usage: "sort by " + strings.Join(search.SortKeys, ", "), Value: "score"},
+		&cli.BoolFlag{Name: "reverse", Usage: "reverse the sort order"},
+		&cli.IntFlag{Name: "limit", Usage: "show at most this many results (0 for all)"},
+		&cli.StringFlag{Name: "format", Usage: "output format: table, json or csv", Value: "table"},
+	)
+
 	RootCmd.Commands = append(RootCmd.Commands, &cli.Command{
 		Name:        "search",
-		Usage:       "tlego search <keyword>",
-		Description: "Search for satellites by name or partial name match.",
+		Usage:       "tlego search [keyword] [filters]",
+		Description: "Search for satellites by name, tolerating typos, and filter them by NORAD ID, international designator, launch year, inclination, period, apogee, perigee, group, and by SATCAT object type, country and status.",
 		Action:      searchSatellites,
 		Category:    "Search",
+		Flags:       flags,
 	})
 }
 
 func searchSatellites(ctx context.Context, cmd *cli.Command) error {
-	args := cmd.Args()
-	if args.Len() == 0 {
-		return fmt.Errorf("please provide a keyword to search for")
+	query, err := searchQuery(cmd)
+	if err != nil {
+		return err
+	}
+	if query.Name == "" && countSet(cmd, "intdes", "launch-year", "group", "norad", "inclination", "period", "apogee", "perigee", "type", "country", "status") == 0 {
+		return fmt.Errorf("please provide a keyword or a filter to search for")
 	}
 
-	keyword := strings.ToLower(args.First())
+	src, err := openSource()
+	if err != nil {
+		return err
+	}
+	candidates, err := searchCandidates(ctx, src, query.Name)
+	if err != nil {
+		return err
+	}
 
-	// Load satellite groups from the configuration
-	config, err := celestrak.ReadCelestrakConfig()
+	// the SATCAT is only required to filter on it; otherwise results just
+	// lack its columns when it cannot be fetched
+	cat, err := loadSATCAT(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to read Celestrak configuration: %w", err)
+		if !query.SATCAT.IsZero() {
+			return fmt.Errorf("failed to load the SATCAT: %w", err)
+		}
+		logger.Warn("Searching without SATCAT data", "error", err)
+	}
+	for i := range candidates {
+		if rec, ok := cat.Lookup(candidates[i].TLE.Elements.CatalogNumber); ok {
+			candidates[i].SATCAT = &rec
+		}
 	}
 
-	fmt.Printf("Searching for satellites matching keyword: %q\n", keyword)
+	results := search.Search(candidates, query)
+	if err := search.Sort(results, cmd.String("sort"), cmd.Bool("reverse")); err != nil {
+		return err
+	}
+	if limit := int(cmd.Int("limit")); limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
 
-	// Iterate through all satellite groups and search for matches
-	var matches []string
-	for _, group := range config.SatelliteGroups {
-		tles, err := celestrak.GetSatelliteGroupTLEs(group.Name, config)
+	format := strings.ToLower(cmd.String("format"))
+	if len(results) == 0 && format == "table" {
+		fmt.Println("No satellites found")
+		return nil
+	}
+	return search.Write(os.Stdout, format, results)
+}
+
+// searchQuery builds the search query from the keyword and the filter flags.
+func searchQuery(cmd *cli.Command) (search.Query, error) {
+	query := search.NewQuery()
+	query.Name = strings.Join(cmd.Args().Slice(), " ")
+	query.IntlDesignator = cmd.String("intdes")
+	query.LaunchYear = int(cmd.Int("launch-year"))
+	query.Group = cmd.String("group")
+	query.SATCAT = satcat.ParseFilter(cmd.String("type"), cmd.String("country"), cmd.String("status"))
+
+	ranges := map[string]*search.Range{
+		"norad":       &query.NoradID,
+		"inclination": &query.Inclination,
+		"period":      &query.Period,
+		"apogee":      &query.Apogee,
+		"perigee":     &query.Perigee,
+	}
+	for _, f := range rangeFlags {
+		parsed, err := search.ParseRange(cmd.String(f.name))
 		if err != nil {
-			fmt.Printf("Warning: Failed to fetch TLEs for group %q: %v\n", group.Name, err)
-			continue
+			return search.Query{}, fmt.Errorf("--%s: %w", f.name, err)
 		}
+		*ranges[f.name] = parsed
+	}
+	return query, nil
+}
 
-		for _, tle := range tles {
-			if strings.Contains(strings.ToLower(tle.Name), keyword) {
-				matches = append(matches, fmt.Sprintf("Name: %s | NORAD ID: %s", tle.Name, tle.NoradID))
-			}
+// searchCandidates lists the satellites to search. Sources without an index
+// can only be searched by name, and carry no group membership.
+func searchCandidates(ctx context.Context, src source.Source, name string) ([]search.Candidate, error) {
+	var candidates []search.Candidate
+	if indexer, ok := src.(source.Indexer); ok {
+		entries, err := indexer.Index(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			candidates = append(candidates, search.Candidate{TLE: entry.TLE, Groups: entry.Groups})
 		}
+		return candidates, nil
 	}
 
-	// Display results
-	if len(matches) == 0 {
-		fmt.Printf("No satellites found matching keyword: %q\n", keyword)
-		return nil
+	if name == "" {
+		return nil, fmt.Errorf("this source can only be searched with a keyword")
 	}
-
-	fmt.Println("Matching Satellites:")
-	for _, match := range matches {
-		fmt.Println(match)
+	tles, err := src.SearchByName(ctx, name)
+	if err != nil {
+		return nil, err
 	}
-
-	return nil
+	for _, t := range tles {
+		candidates = append(candidates, search.Candidate{TLE: t})
+	}
+	return candidates, nil
 }
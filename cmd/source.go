@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"github.com/Mohammed-Ashour/tlego/pkg/source"
+)
+
+// sourceSpec is the --source flag: where element sets come from.
+var sourceSpec string
+
+// openSource opens the source selected with --source.
+func openSource() (source.Source, error) {
+	return source.Open(sourceSpec)
+}
@@ -4,35 +4,101 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
+	"github.com/Mohammed-Ashour/tlego/pkg/source"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
 	"github.com/urfave/cli/v3"
 )
 
 func init() {
 	RootCmd.Commands = append(RootCmd.Commands, &cli.Command{
 		Name:        "tle",
-		Usage:       "tlego tle <NORAD-ID>",
-		Description: "Fetches the Two-Line Element (TLE) data for a satellite identified by its NORAD ID. The NORAD ID is a unique identifier assigned to each satellite. Example: tlego tle 25544 (for the ISS).",
+		Usage:       "tlego tle <NORAD-ID>... | --intdes <YYYY-NNN[A]> | --name <text> | --sup <source>",
+		Description: "Fetches the Two-Line Element (TLE) data for one or more satellites identified by their NORAD IDs, or for every satellite matching an international designator, a name or a supplemental GP source. The NORAD ID is a unique identifier assigned to each satellite. Example: tlego tle 25544 (for the ISS).",
 		Action:      tleGrep,
 		Category:    "TLE",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "intdes",
+				Usage: "international designator of an object (1998-067A) or a launch (1998-067)",
+			},
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "fetch every satellite whose name contains this text",
+			},
+			&cli.StringFlag{
+				Name:  "sup",
+				Usage: "fetch supplemental GP data of a source (e.g. starlink, oneweb, planet)",
+			},
+		},
 	})
 }
+
 func tleGrep(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args()
-	if args.Len() == 0 {
+	src, err := openSource()
+	if err != nil {
+		return err
+	}
+
+	var tles []tle.TLE
+	switch {
+	case countSet(cmd, "intdes", "name", "sup") > 0:
+		if countSet(cmd, "intdes", "name", "sup") > 1 || args.Len() > 0 {
+			return fmt.Errorf("use only one of NORAD IDs, --intdes, --name and --sup")
+		}
+		// these are CelesTrak queries, with no counterpart in other sources
+		celestrakSrc, ok := src.(*source.CelesTrak)
+		if !ok {
+			return fmt.Errorf("--intdes, --name and --sup query CelesTrak and cannot be used with --source %s", sourceSpec)
+		}
+		client := celestrakSrc.Client
+		switch {
+		case cmd.IsSet("intdes"):
+			tles, err = client.GetSatelliteTLEsByIntlDesignator(ctx, cmd.String("intdes"))
+		case cmd.IsSet("name"):
+			tles, err = client.GetSatelliteTLEsByName(ctx, cmd.String("name"))
+		default:
+			tles, err = client.GetSupplementalTLEs(ctx, cmd.String("sup"))
+		}
+	case args.Len() == 0:
 		fmt.Println("Please provide a NORAD ID for the requested sat")
 		return fmt.Errorf("please provide a NORAD ID for the requested sat")
+	case args.Len() == 1:
+		noradId, err := parseNoradID(args.First())
+		if err != nil {
+			return err
+		}
+		t, err := src.GetSatelliteTLEByNoradID(ctx, noradId)
+		if err != nil {
+			return err
+		}
+		tles = []tle.TLE{t}
+	default:
+		noradIds := make([]string, 0, args.Len())
+		for _, arg := range args.Slice() {
+			noradId, err := parseNoradID(arg)
+			if err != nil {
+				return err
+			}
+			noradIds = append(noradIds, noradId)
+		}
+		// print what was found before reporting the IDs that were not
+		tles, err = source.GetSatelliteTLEsByNoradIDs(ctx, src, noradIds)
 	}
 
-	noradId := args.First()
-	err := validateNoradID(noradId)
-	if err != nil {
-		return err
+	for _, t := range tles {
+		fmt.Println(t)
 	}
-	tle, err := celestrak.GetSatelliteTLEByNoradID(noradId)
-	if err != nil {
-		return err
+	return err
+}
+
+// countSet returns how many of the named flags were given.
+func countSet(cmd *cli.Command, names ...string) int {
+	n := 0
+	for _, name := range names {
+		if cmd.IsSet(name) {
+			n++
+		}
 	}
-	fmt.Println(tle)
-	return nil
+	return n
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -9,17 +10,27 @@ import (
 	"time"
 
 	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/satellite"
-	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
+	"github.com/Mohammed-Ashour/tlego/pkg/doppler"
+	"github.com/Mohammed-Ashour/tlego/pkg/hamlib"
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
 	"github.com/urfave/cli/v3"
 )
 
 func init() {
 	RootCmd.Commands = append(RootCmd.Commands, &cli.Command{
 		Name:        "track",
-		Usage:       "tlego track <NORAD-ID>",
-		Description: "Continuously track the real-time position of a satellite using its NORAD ID.",
+		Usage:       "tlego track <NORAD-ID> [--lat <deg> --lon <deg> [--freq <freq>] [--rotator <host:port>] [--rig <host:port>]]",
+		Description: "Continuously track the real-time position of a satellite using its NORAD ID. With an observer, also show the look angles, and with --freq the Doppler-corrected downlink frequency. --rotator steers an antenna through hamlib rotctld and --rig keeps a radio tuned through rigctld.",
 		Action:      trackSatellite,
 		Category:    "Tracking",
+		Flags: append(observerFlags(false),
+			&cli.StringFlag{Name: "freq", Usage: "downlink frequency to correct for Doppler, e.g. 145.8MHz (needs --lat and --lon)"},
+			&cli.StringFlag{Name: "rotator", Usage: "rotctld address to steer, host or host:port (default port " + hamlib.RotatorPort + ")"},
+			&cli.StringFlag{Name: "rig", Usage: "rigctld address to tune to --freq, host or host:port (default port " + hamlib.RigPort + ")"},
+			&cli.DurationFlag{Name: "update-rate", Usage: "time between updates", Value: time.Second},
+			&cli.FloatFlag{Name: "elevation-mask", Usage: "elevation in degrees below which the rotator is not moved"},
+			&cli.BoolFlag{Name: "flip", Usage: "follow passes through north flipped over the zenith, for rotators with 0-180° elevation"},
+		),
 	})
 }
 
@@ -29,20 +40,83 @@ func trackSatellite(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("please provide a NORAD ID for the satellite to track")
 	}
 
-	noradID := args.First()
-	err := validateNoradID(noradID)
+	noradID, err := parseNoradID(args.First())
 	if err != nil {
 		return err
 	}
 
+	// The observer and frequency are optional; they add look angles and
+	// the corrected downlink to each line.
+	var site *observer.Observer
+	if hasObserver(cmd) {
+		o, err := observerFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		site = &o
+	}
+	var freq float64
+	if cmd.IsSet("freq") {
+		if site == nil {
+			return errors.New("--freq needs the observer position from --lat and --lon")
+		}
+		if freq, err = doppler.ParseFrequency(cmd.String("freq")); err != nil {
+			return fmt.Errorf("invalid --freq: %w", err)
+		}
+	}
+
+	if (cmd.IsSet("rotator") || cmd.IsSet("rig")) && site == nil {
+		return errors.New("--rotator and --rig need the observer position from --lat and --lon")
+	}
+	if cmd.IsSet("rig") && freq == 0 {
+		return errors.New("--rig needs the downlink frequency from --freq")
+	}
+	rate := cmd.Duration("update-rate")
+	if rate <= 0 {
+		return fmt.Errorf("--update-rate must be positive, got %v", rate)
+	}
+
 	// Fetch TLE data for the satellite
-	tle, err := celestrak.GetSatelliteTLEByNoradID(noradID)
+	src, err := openSource()
+	if err != nil {
+		return err
+	}
+	tle, err := src.GetSatelliteTLEByNoradID(ctx, noradID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch TLE for NORAD ID %s: %w", noradID, err)
 	}
 
 	// Create a satellite object from the TLE
-	sat := satellite.NewSatelliteFromTLE(tle, satellite.GravityWGS84)
+	sat := satellite.TLEToSat(tle.Line1.LineString, tle.Line2.LineString, satellite.GravityWGS84)
+	var prop observer.Propagator
+	if site != nil {
+		if prop, err = observer.NewSGP4(tle); err != nil {
+			return err
+		}
+	}
+
+	// Connect the antenna rotator and the radio
+	control := &hamlib.Controller{
+		Downlink:     freq,
+		MinElevation: cmd.Float("elevation-mask"),
+		Flip:         cmd.Bool("flip"),
+		Propagator:   prop,
+	}
+	if site != nil {
+		control.Observer = *site
+	}
+	if addr := cmd.String("rotator"); addr != "" {
+		if control.Rotator, err = hamlib.DialRotator(ctx, addr); err != nil {
+			return err
+		}
+		defer control.Rotator.Close()
+	}
+	if addr := cmd.String("rig"); addr != "" {
+		if control.Rig, err = hamlib.DialRig(ctx, addr); err != nil {
+			return err
+		}
+		defer control.Rig.Close()
+	}
 
 	// Set up signal handling for graceful exit
 	signalChan := make(chan os.Signal, 1)
@@ -52,7 +126,7 @@ func trackSatellite(ctx context.Context, cmd *cli.Command) error {
 	fmt.Println("Press Ctrl+C to stop tracking.")
 
 	// Start tracking loop
-	ticker := time.NewTicker(1 * time.Second)
+	ticker := time.NewTicker(rate)
 	defer ticker.Stop()
 
 	for {
@@ -68,8 +142,24 @@ func trackSatellite(ctx context.Context, cmd *cli.Command) error {
 			// Format altitude string with explanation for negative values
 
 			// Display the position
-			fmt.Printf("\rTime: %s | Latitude: %.6f | Longitude: %.6f | Altitude: %.6f\n",
+			line := fmt.Sprintf("Time: %s | Latitude: %.6f | Longitude: %.6f | Altitude: %.6f",
 				now.Format(time.RFC3339), latitude, longitude, altitude)
+			if site != nil {
+				look, err := site.LookAt(prop, now)
+				if err != nil {
+					return fmt.Errorf("failed to compute look angles: %w", err)
+				}
+				line += fmt.Sprintf(" | Azimuth: %.2f° | Elevation: %.2f° | Range Rate: %.3f km/s",
+					look.Azimuth, look.Elevation, look.RangeRate)
+				if freq != 0 {
+					corrected := doppler.Downlink(freq, look.RangeRate)
+					line += fmt.Sprintf(" | Downlink: %.6f MHz (%+.0f Hz)", corrected/1e6, corrected-freq)
+				}
+				if err := control.Update(now, look); err != nil {
+					return fmt.Errorf("failed to update rotator or rig: %w", err)
+				}
+			}
+			fmt.Printf("\r%s\n", line)
 		}
 	}
 }
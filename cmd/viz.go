@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
-	"strconv"
 
-	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
 	"github.com/Mohammed-Ashour/tlego/pkg/logger"
 	visual "github.com/Mohammed-Ashour/tlego/pkg/visual"
 	"github.com/urfave/cli/v3"
@@ -27,15 +25,15 @@ func visualizeSatelliteOrbit(ctx context.Context, cmd *cli.Command) error {
 	if args.Len() != 1 {
 		return fmt.Errorf("the viz (visualize) command only supports on argument mode at the moment")
 	}
-	noradId := args.First()
-	err := validateNoradID(noradId)
+	noradId, err := parseNoradID(args.First())
 	if err != nil {
 		return err
 	}
-	if _, err := strconv.Atoi(noradId); err != nil {
-		return fmt.Errorf("noradId is only digits %s was passed.\n", noradId)
+	src, err := openSource()
+	if err != nil {
+		return err
 	}
-	tle, err := celestrak.GetSatelliteTLEByNoradID(noradId)
+	tle, err := src.GetSatelliteTLEByNoradID(ctx, noradId)
 	if err != nil {
 		return fmt.Errorf("failed to fetch TLE for NORAD ID %s: %w", noradId, err)
 	}
@@ -4,9 +4,9 @@ import (
 	"os"
 
 	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/satellite"
-	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/tle"
 	viz "github.com/Mohammed-Ashour/tlego/pkg/locate"
 	"github.com/Mohammed-Ashour/tlego/pkg/logger"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
 	visual "github.com/Mohammed-Ashour/tlego/pkg/visual"
 )
 
@@ -37,14 +37,10 @@ func main() {
 	logger.Info("Processing TLE",
 		"classification", t.Line1.Classification,
 		"satellite_id", t.Line1.SataliteID)
-	s := satellite.NewSatelliteFromTLE(t, satellite.GravityWGS84)
+	s := satellite.TLEToSat(t.Line1.LineString, t.Line2.LineString, satellite.GravityWGS84)
 
 	// Use epoch time instead of current time
-	epochTime, err := t.Time()
-	if err != nil {
-		logger.Error("Failed to get epoch time", "error", err)
-		return
-	}
+	epochTime := t.Elements.Epoch
 
 	lat, long, alt, _ := s.Locate(epochTime)
 	logger.Info("Satellite position calculated",
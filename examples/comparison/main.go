@@ -6,9 +6,9 @@ import (
 	"time"
 
 	gosatellite "github.com/Mohammed-Ashour/go-satellite-v2/pkg/satellite"
-	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/tle"
 	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
 	"github.com/Mohammed-Ashour/tlego/pkg/locate"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
 )
 
 func main() {
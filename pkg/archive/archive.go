@@ -0,0 +1,202 @@
+// Package archive keeps every element set tlego has fetched, so positions at
+// past times can be computed from the element set that was current then
+// rather than from the latest download.
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// ARCHIVE_DIR is the directory of the default archive. Archiving is opt-in:
+// while it is empty there is no default archive. The CLI keeps it in the
+// download directory.
+var ARCHIVE_DIR = ""
+
+// ErrNotArchived is returned when the archive holds no element set of a satellite.
+var ErrNotArchived = errors.New("no archived element sets")
+
+// Archive is an append-only store of element sets with one TLE file per
+// satellite. Element sets are identified by their epoch and element set
+// number; adding one that is already archived does nothing.
+type Archive struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+// New returns the archive kept in dir.
+func New(dir string) *Archive {
+	return &Archive{Dir: dir}
+}
+
+// Default returns the archive in ARCHIVE_DIR, or nil if archiving is off.
+func Default() *Archive {
+	if ARCHIVE_DIR == "" {
+		return nil
+	}
+	return New(ARCHIVE_DIR)
+}
+
+// Add appends the element sets that are not archived yet and returns how
+// many were added. Element sets without TLE lines, such as OMMs of catalog
+// numbers too large for a TLE, are skipped.
+func (a *Archive) Add(tles []tle.TLE) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	bySatellite := map[int][]tle.TLE{}
+	var order []int
+	for _, t := range tles {
+		if len(t.Line1.LineString) < 69 || len(t.Line2.LineString) < 69 {
+			continue
+		}
+		n := t.Elements.CatalogNumber
+		if _, ok := bySatellite[n]; !ok {
+			order = append(order, n)
+		}
+		bySatellite[n] = append(bySatellite[n], t)
+	}
+	if len(order) == 0 {
+		return 0, nil
+	}
+	if err := os.MkdirAll(a.Dir, os.ModePerm); err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for _, n := range order {
+		count, err := a.append(n, bySatellite[n])
+		added += count
+		if err != nil {
+			return added, err
+		}
+	}
+	return added, nil
+}
+
+func (a *Archive) append(catalogNumber int, tles []tle.TLE) (int, error) {
+	filename := a.path(catalogNumber)
+	archived, err := readFile(filename)
+	if err != nil {
+		return 0, err
+	}
+	seen := map[key]bool{}
+	for _, t := range archived {
+		seen[keyOf(t)] = true
+	}
+
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	encoder := tle.NewEncoder(file)
+	added := 0
+	for _, t := range tles {
+		if seen[keyOf(t)] {
+			continue
+		}
+		seen[keyOf(t)] = true
+		if err := encoder.Encode(t); err != nil {
+			return added, fmt.Errorf("%s: %w", filename, err)
+		}
+		added++
+	}
+	return added, nil
+}
+
+// History returns every archived element set of a satellite, oldest first.
+func (a *Archive) History(noradID string) ([]tle.TLE, error) {
+	catalogNumber, err := tle.ParseCatalogNumber(noradID)
+	if err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	tles, err := readFile(a.path(catalogNumber))
+	a.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if len(tles) == 0 {
+		return nil, fmt.Errorf("NORAD ID %s: %w", noradID, ErrNotArchived)
+	}
+	sort.SliceStable(tles, func(i, j int) bool {
+		return tles[i].Elements.Epoch.Before(tles[j].Elements.Epoch)
+	})
+	return tles, nil
+}
+
+// Closest returns the archived element set of a satellite whose epoch is
+// closest to t.
+func (a *Archive) Closest(noradID string, t time.Time) (tle.TLE, error) {
+	tles, err := a.History(noradID)
+	if err != nil {
+		return tle.TLE{}, err
+	}
+	return Closest(tles, t), nil
+}
+
+// Closest returns the element set whose epoch is closest to t. tles must not
+// be empty.
+func Closest(tles []tle.TLE, t time.Time) tle.TLE {
+	best := tles[0]
+	for _, candidate := range tles[1:] {
+		if EpochDistance(candidate, t) < EpochDistance(best, t) {
+			best = candidate
+		}
+	}
+	return best
+}
+
+func (a *Archive) path(catalogNumber int) string {
+	return filepath.Join(a.Dir, strconv.Itoa(catalogNumber)+".tle")
+}
+
+// key identifies an element set by its epoch as written in the TLE (line 1
+// columns 19-32) and its element set number. The TLE epoch is used rather
+// than Elements.Epoch because sets read from OMMs keep microseconds that
+// the archived TLE lines round away.
+type key struct {
+	epoch            string
+	elementSetNumber int
+}
+
+func keyOf(t tle.TLE) key {
+	return key{t.Line1.LineString[18:32], t.Elements.ElementSetNumber}
+}
+
+// readFile reads an archive file; a missing file holds no element sets.
+func readFile(filename string) ([]tle.TLE, error) {
+	file, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	tles, err := tle.ReadTLEs(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return tles, nil
+}
+
+// EpochDistance returns how far the epoch of an element set is from t.
+func EpochDistance(set tle.TLE, t time.Time) time.Duration {
+	d := set.Elements.Epoch.Sub(t)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
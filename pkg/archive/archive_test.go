@@ -0,0 +1,113 @@
+package archive
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+const elementSets = `ISS (ZARYA)
+1 25544U 98067A   24001.50000000  .00016717  00000-0  10270-3 0  9005
+2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537
+ISS (ZARYA)
+1 25544U 98067A   24010.50000000  .00016717  00000-0  10270-3 0  9005
+2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537
+CSS (TIANHE)
+1 48274U 21035A   24057.50000000  .00020000  00000-0  22000-3 0  9990
+2 48274  41.4700 100.0000 0005000  90.0000 270.0000 15.60000000150000
+`
+
+const laterISS = `ISS (ZARYA)
+1 25544U 98067A   24005.50000000  .00016717  00000-0  10270-3 0  9005
+2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537
+`
+
+func readTLEs(t *testing.T, data string) []tle.TLE {
+	tles, err := tle.ReadTLEs(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tles
+}
+
+func TestArchive(t *testing.T) {
+	a := New(t.TempDir())
+
+	added, err := a.Add(readTLEs(t, elementSets))
+	if err != nil || added != 3 {
+		t.Fatalf("Add() = %d, %v; want 3", added, err)
+	}
+	// adding the same element sets again is a no-op
+	added, err = a.Add(readTLEs(t, elementSets+laterISS))
+	if err != nil || added != 1 {
+		t.Fatalf("Add() = %d, %v; want 1", added, err)
+	}
+
+	history, err := a.History("25544")
+	if err != nil || len(history) != 3 {
+		t.Fatalf("History() = %d element sets, %v", len(history), err)
+	}
+	for i, day := range []int{1, 5, 10} {
+		if history[i].Elements.Epoch.YearDay() != day {
+			t.Errorf("history[%d] has day %d, want %d", i, history[i].Elements.Epoch.YearDay(), day)
+		}
+	}
+
+	tests := []struct {
+		at   time.Time
+		want int
+	}{
+		{time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), 5},
+		{time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC), 10},
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+	}
+	for _, tt := range tests {
+		closest, err := a.Closest("25544", tt.at)
+		if err != nil || closest.Elements.Epoch.YearDay() != tt.want {
+			t.Errorf("Closest(%s) = day %d, %v; want day %d", tt.at, closest.Elements.Epoch.YearDay(), err, tt.want)
+		}
+	}
+
+	if _, err := a.Closest("99999", time.Now()); !errors.Is(err, ErrNotArchived) {
+		t.Errorf("expected ErrNotArchived, got %v", err)
+	}
+}
+
+// ommISS has an epoch with microseconds, which its TLE lines round away.
+const ommISS = `[{
+    "OBJECT_NAME": "ISS (ZARYA)",
+    "OBJECT_ID": "1998-067A",
+    "EPOCH": "2024-02-26T21:54:52.995744",
+    "MEAN_MOTION": 15.49808581,
+    "ECCENTRICITY": 0.0005713,
+    "INCLINATION": 51.6403,
+    "RA_OF_ASC_NODE": 179.4367,
+    "ARG_OF_PERICENTER": 6.8573,
+    "MEAN_ANOMALY": 94.2478,
+    "EPHEMERIS_TYPE": 0,
+    "CLASSIFICATION_TYPE": "U",
+    "NORAD_CAT_ID": 25544,
+    "ELEMENT_SET_NO": 999,
+    "REV_AT_EPOCH": 441289,
+    "BSTAR": 0.00030362,
+    "MEAN_MOTION_DOT": 0.0001648,
+    "MEAN_MOTION_DDOT": 0
+}]`
+
+func TestArchiveOMM(t *testing.T) {
+	a := New(t.TempDir())
+	for i, want := range []int{1, 0} {
+		tles, err := tle.ReadFormat(strings.NewReader(ommISS), tle.FormatJSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+		added, err := a.Add(tles)
+		if err != nil || added != want {
+			t.Fatalf("Add() #%d = %d, %v; want %d", i+1, added, err, want)
+		}
+	}
+}
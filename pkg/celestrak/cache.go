@@ -0,0 +1,89 @@
+package celestrak
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CACHE_MAX_AGE is the default Client.CacheMaxAge: how long a downloaded
+// response is served from the download directory before CelesTrak is asked
+// again. CelesTrak only refreshes GP data every few hours, so querying more
+// often just costs bandwidth.
+var CACHE_MAX_AGE = 2 * time.Hour
+
+// OFFLINE is the default Client.Offline: serve every query from the download
+// directory, whatever its age, and never touch the network.
+var OFFLINE = false
+
+// ErrNotCached is returned in offline mode when a query has no cached response.
+var ErrNotCached = errors.New("not in the local cache")
+
+// cacheEntry is the metadata kept next to each cached response.
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// fresh reports whether the cached response can be served without revalidation.
+func (e cacheEntry) fresh(now time.Time, maxAge time.Duration) bool {
+	return now.Sub(e.FetchedAt) < maxAge
+}
+
+func cacheMetaPath(filename string) string {
+	return filename + ".meta.json"
+}
+
+// readCacheEntry returns the metadata of the response cached in filename for url.
+// It reports false when there is no usable entry, including when the cached
+// response belongs to a different query.
+func readCacheEntry(filename, url string) (cacheEntry, bool) {
+	if _, err := os.Stat(filename); err != nil {
+		return cacheEntry{}, false
+	}
+	data, err := os.ReadFile(cacheMetaPath(filename))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != url {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func writeCacheEntry(filename string, entry cacheEntry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(cacheMetaPath(filename), data)
+}
+
+// writeFileAtomic writes data to a temporary file next to filename and renames
+// it into place, so readers never see a partially written file.
+func writeFileAtomic(filename string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to replace %s: %w", filename, err)
+	}
+	return nil
+}
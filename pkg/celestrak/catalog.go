@@ -0,0 +1,267 @@
+package celestrak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/logger"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+const (
+	// indexDir is the subdirectory of the download directory that holds
+	// indexes, apart from the element set files so the download directory
+	// can still be opened as a source.
+	indexDir = "index"
+	// catalogFile is the name of the cached catalog index in indexDir.
+	catalogFile = "catalog.json"
+)
+
+// Catalog is an index of every satellite in the configured groups. A
+// satellite listed in several groups appears once, with its most recent
+// element set and every group it belongs to.
+type Catalog struct {
+	BuiltAt      time.Time
+	Groups       []string // groups the index was built from
+	GroupsHash   string   // hash of the group definitions, to notice edits
+	FailedGroups []string // groups that could not be fetched
+	Satellites   []CatalogEntry
+}
+
+// CatalogEntry is one satellite of a Catalog.
+type CatalogEntry struct {
+	TLE    tle.TLE
+	Groups []string
+}
+
+// Catalog returns the catalog index of the groups in config, read from the
+// download directory while it is younger than CacheMaxAge and was built from
+// the same group definitions, and rebuilt with BuildCatalog otherwise. In
+// offline mode a cached index is used whatever its age.
+func (c *Client) Catalog(ctx context.Context, config CelestrakConfig) (*Catalog, error) {
+	dir := filepath.Join(c.DownloadDir, indexDir)
+	filename := filepath.Join(dir, catalogFile)
+	cached, err := readCatalog(filename)
+	if err == nil && cached.usable(config, time.Now(), c.CacheMaxAge, c.Offline) {
+		logger.Debug("Serving catalog from cache", "file", filename, "built_at", cached.BuiltAt)
+		return cached, nil
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warn("Ignoring unreadable catalog cache", "file", filename, "error", err)
+	}
+
+	catalog, err := c.BuildCatalog(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureDownloadDir(dir); err == nil {
+		if err := writeCatalog(filename, catalog); err != nil {
+			logger.Warn("Failed to cache catalog", "file", filename, "error", err)
+		}
+	}
+	return catalog, nil
+}
+
+// BuildCatalog fetches every group in config, Workers at a time, and indexes
+// their satellites. Groups that fail are logged and listed in FailedGroups;
+// it only fails when none could be fetched.
+func (c *Client) BuildCatalog(ctx context.Context, config CelestrakConfig) (*Catalog, error) {
+	groups := config.SatelliteGroups
+	results := make([][]tle.TLE, len(groups))
+	errs := make([]error, len(groups))
+
+	workers := max(c.Workers, 1)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(groups)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = c.GetSatelliteGroupTLEs(ctx, groups[i].Name, config)
+			}
+		}()
+	}
+feed:
+	for i := range groups {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	catalog := &Catalog{BuiltAt: time.Now(), GroupsHash: groupsHash(groups)}
+	index := map[int]int{}
+	for i, group := range groups {
+		catalog.Groups = append(catalog.Groups, group.Name)
+		if errs[i] != nil {
+			logger.Warn("Failed to fetch TLEs for group", "group", group.Name, "error", errs[i])
+			catalog.FailedGroups = append(catalog.FailedGroups, group.Name)
+			continue
+		}
+		for _, t := range results[i] {
+			j, seen := index[t.Elements.CatalogNumber]
+			if !seen {
+				index[t.Elements.CatalogNumber] = len(catalog.Satellites)
+				catalog.Satellites = append(catalog.Satellites, CatalogEntry{TLE: t, Groups: []string{group.Name}})
+				continue
+			}
+			entry := &catalog.Satellites[j]
+			if t.Elements.Epoch.After(entry.TLE.Elements.Epoch) {
+				entry.TLE = t
+			}
+			if !slices.Contains(entry.Groups, group.Name) {
+				entry.Groups = append(entry.Groups, group.Name)
+			}
+		}
+	}
+	if len(groups) > 0 && len(catalog.FailedGroups) == len(groups) {
+		return nil, fmt.Errorf("failed to fetch all %d groups: %w", len(groups), errs[0])
+	}
+
+	sort.Slice(catalog.Satellites, func(i, j int) bool {
+		return catalog.Satellites[i].TLE.Elements.CatalogNumber < catalog.Satellites[j].TLE.Elements.CatalogNumber
+	})
+	return catalog, nil
+}
+
+// SearchByName returns the satellites whose name contains name, ignoring case.
+func (cat *Catalog) SearchByName(name string) []CatalogEntry {
+	name = strings.ToLower(name)
+	var matches []CatalogEntry
+	for _, entry := range cat.Satellites {
+		if strings.Contains(strings.ToLower(entry.TLE.Name), name) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+// Lookup returns the satellite with a catalog number.
+func (cat *Catalog) Lookup(catalogNumber int) (CatalogEntry, bool) {
+	i := sort.Search(len(cat.Satellites), func(i int) bool {
+		return cat.Satellites[i].TLE.Elements.CatalogNumber >= catalogNumber
+	})
+	if i < len(cat.Satellites) && cat.Satellites[i].TLE.Elements.CatalogNumber == catalogNumber {
+		return cat.Satellites[i], true
+	}
+	return CatalogEntry{}, false
+}
+
+// usable reports whether a cached catalog can be served for config.
+func (cat *Catalog) usable(config CelestrakConfig, now time.Time, maxAge time.Duration, offline bool) bool {
+	if cat.GroupsHash != groupsHash(config.SatelliteGroups) {
+		return false
+	}
+	if offline {
+		return true
+	}
+	return len(cat.FailedGroups) == 0 && now.Sub(cat.BuiltAt) < maxAge
+}
+
+// groupsHash hashes the group definitions, built-in and user alike, so an
+// index is rebuilt when a group is added, removed or edited.
+func groupsHash(groups []SatelliteGroup) string {
+	data, err := json.Marshal(groups)
+	if err != nil {
+		return ""
+	}
+	h := fnv.New64a()
+	h.Write(data)
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// catalogJSON is the on-disk form of a Catalog. Element sets are kept as
+// their original lines, or as OMMs when they have none.
+type catalogJSON struct {
+	BuiltAt      time.Time          `json:"built_at"`
+	Groups       []string           `json:"groups"`
+	GroupsHash   string             `json:"groups_hash"`
+	FailedGroups []string           `json:"failed_groups,omitempty"`
+	Satellites   []catalogEntryJSON `json:"satellites"`
+}
+
+type catalogEntryJSON struct {
+	Name   string   `json:"name"`
+	Line1  string   `json:"line1,omitempty"`
+	Line2  string   `json:"line2,omitempty"`
+	OMM    *tle.OMM `json:"omm,omitempty"`
+	Groups []string `json:"groups"`
+}
+
+func writeCatalog(filename string, cat *Catalog) error {
+	out := catalogJSON{
+		BuiltAt:      cat.BuiltAt,
+		Groups:       cat.Groups,
+		GroupsHash:   cat.GroupsHash,
+		FailedGroups: cat.FailedGroups,
+		Satellites:   make([]catalogEntryJSON, 0, len(cat.Satellites)),
+	}
+	for _, entry := range cat.Satellites {
+		e := catalogEntryJSON{
+			Name:   entry.TLE.Name,
+			Line1:  entry.TLE.Line1.LineString,
+			Line2:  entry.TLE.Line2.LineString,
+			Groups: entry.Groups,
+		}
+		if e.Line1 == "" {
+			omm := tle.NewOMM(entry.TLE)
+			e.OMM = &omm
+		}
+		out.Satellites = append(out.Satellites, e)
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filename, data)
+}
+
+func readCatalog(filename string) (*Catalog, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var in catalogJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, err
+	}
+
+	cat := &Catalog{
+		BuiltAt:      in.BuiltAt,
+		Groups:       in.Groups,
+		GroupsHash:   in.GroupsHash,
+		FailedGroups: in.FailedGroups,
+		Satellites:   make([]CatalogEntry, 0, len(in.Satellites)),
+	}
+	for _, e := range in.Satellites {
+		var t tle.TLE
+		var err error
+		if e.OMM != nil {
+			t, err = e.OMM.TLE()
+		} else {
+			t, err = tle.ParseTLE(e.Line1, e.Line2, e.Name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name, err)
+		}
+		cat.Satellites = append(cat.Satellites, CatalogEntry{TLE: t, Groups: e.Groups})
+	}
+	return cat, nil
+}
@@ -1,9 +1,19 @@
 package celestrak
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
 )
 
 const testConfig = `
@@ -33,6 +43,7 @@ func setupTestServer() *httptest.Server {
 }
 
 func TestReadCelestrakConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
 
 	// Test config reading
 	config, err := ReadCelestrakConfig()
@@ -48,15 +59,25 @@ func TestReadCelestrakConfig(t *testing.T) {
 
 }
 
+// useTempDownloadDir points DOWNLOAD_DIR at a temporary directory for the
+// package-level helpers, which create it.
+func useTempDownloadDir(t *testing.T) {
+	dir := DOWNLOAD_DIR
+	DOWNLOAD_DIR = t.TempDir()
+	t.Cleanup(func() { DOWNLOAD_DIR = dir })
+}
+
 func TestGetSatelliteTLEByNoradID(t *testing.T) {
 	// Setup test server
 	server := setupTestServer()
 	defer server.Close()
-	// Override celestrak URL for testing
-	CELESTRAK_URL = server.URL + "?CATNR=NORADID&FORMAT=TLE"
+	// Point a client at the test server for testing
+	client := NewClient()
+	client.BaseURL = server.URL
+	client.DownloadDir = t.TempDir()
 
 	// Test TLE download
-	tle, err := GetSatelliteTLEByNoradID("25544")
+	tle, err := client.GetSatelliteTLEByNoradID(context.Background(), "25544")
 	if err != nil {
 		t.Errorf("GetSatelliteTLEByNoradID() error = %v", err)
 		return
@@ -72,6 +93,8 @@ func TestGetSatelliteGroupTLEs(t *testing.T) {
 	server := setupTestServer()
 	defer server.Close()
 
+	useTempDownloadDir(t)
+
 	// Create temporary config with test server URL
 
 	tempConfig := CelestrakConfig{
@@ -100,7 +123,8 @@ func TestDownloadTLEs(t *testing.T) {
 	defer server.Close()
 
 	// Test TLE download
-	tles, err := DownloadTLEs(server.URL, "test.tle")
+	filename := filepath.Join(t.TempDir(), "test.tle")
+	tles, err := DownloadTLEs(server.URL, filename)
 	if err != nil {
 		t.Errorf("DownloadTLEs() error = %v", err)
 		return
@@ -111,8 +135,492 @@ func TestDownloadTLEs(t *testing.T) {
 	}
 
 	// Test error cases
-	_, err = DownloadTLEs("invalid-url", "test.tle")
+	_, err = DownloadTLEs("invalid-url", filename)
 	if err == nil {
 		t.Error("Expected error for invalid URL")
 	}
 }
+
+func TestDownloadTLEsOMMFormat(t *testing.T) {
+	const ommJSON = `[{"OBJECT_NAME":"ISS (ZARYA)","OBJECT_ID":"1998-067A","EPOCH":"2024-02-26T21:54:52.995744",
+"MEAN_MOTION":15.49808581,"ECCENTRICITY":0.0005713,"INCLINATION":51.6403,"RA_OF_ASC_NODE":179.4367,
+"ARG_OF_PERICENTER":6.8573,"MEAN_ANOMALY":94.2478,"EPHEMERIS_TYPE":0,"CLASSIFICATION_TYPE":"U",
+"NORAD_CAT_ID":25544,"ELEMENT_SET_NO":999,"REV_AT_EPOCH":44128,"BSTAR":0.00030362,
+"MEAN_MOTION_DOT":0.0001648,"MEAN_MOTION_DDOT":0}]`
+
+	var gotFormat string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotFormat = r.URL.Query().Get("FORMAT")
+		w.Write([]byte(ommJSON))
+	}))
+	defer server.Close()
+
+	FORMAT = tle.FormatJSON
+	defer func() { FORMAT = tle.FormatTLE }()
+
+	tles, err := DownloadTLEs(server.URL+"?GROUP=stations&FORMAT=tle", filepath.Join(t.TempDir(), "test.tle"))
+	if err != nil {
+		t.Fatalf("DownloadTLEs() error = %v", err)
+	}
+	if gotFormat != "json" {
+		t.Errorf("expected FORMAT=json in request, got %q", gotFormat)
+	}
+	if len(tles) != 1 || tles[0].NoradID != "25544" {
+		t.Errorf("unexpected TLEs %+v", tles)
+	}
+}
+
+func TestDownloadTLEsCache(t *testing.T) {
+	var requests, revalidations int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidations++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(testTLE))
+	}))
+	defer server.Close()
+
+	filename := filepath.Join(t.TempDir(), "25544.tle")
+	download := func() {
+		t.Helper()
+		tles, err := DownloadTLEs(server.URL+"?CATNR=25544", filename)
+		if err != nil {
+			t.Fatalf("DownloadTLEs() error = %v", err)
+		}
+		if len(tles) != 1 {
+			t.Fatalf("Expected 1 TLE, got %d", len(tles))
+		}
+	}
+
+	download()
+	download()
+	if requests != 1 {
+		t.Errorf("expected fresh cache to be served without a request, got %d requests", requests)
+	}
+
+	CACHE_MAX_AGE = 0
+	defer func() { CACHE_MAX_AGE = 2 * time.Hour }()
+	download()
+	if requests != 2 || revalidations != 1 {
+		t.Errorf("expected one conditional request, got %d requests and %d revalidations", requests, revalidations)
+	}
+
+	OFFLINE = true
+	defer func() { OFFLINE = false }()
+	download()
+	if requests != 2 {
+		t.Errorf("expected no request in offline mode, got %d requests", requests)
+	}
+
+	_, err := DownloadTLEs(server.URL+"?CATNR=5", filepath.Join(t.TempDir(), "5.tle"))
+	if !errors.Is(err, ErrNotCached) {
+		t.Errorf("expected ErrNotCached in offline mode, got %v", err)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	var requests int
+	var userAgent, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		userAgent, path = r.UserAgent(), r.URL.Path
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(testTLE))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.BaseURL = server.URL
+	client.UserAgent = "tlego-test"
+	client.RetryBackoff = time.Millisecond
+	client.DownloadDir = t.TempDir()
+
+	tle, err := client.GetSatelliteTLEByNoradID(context.Background(), "25544")
+	if err != nil {
+		t.Fatalf("GetSatelliteTLEByNoradID() error = %v", err)
+	}
+	if tle.NoradID != "25544" || requests != 3 {
+		t.Errorf("got NORAD ID %q after %d requests, want 25544 after 3", tle.NoradID, requests)
+	}
+	if userAgent != "tlego-test" || path != "/NORAD/elements/gp.php" {
+		t.Errorf("unexpected request: User-Agent %q, path %q", userAgent, path)
+	}
+
+	client.MaxRetries = 1
+	client.DownloadDir = t.TempDir()
+	requests = 0
+	if _, err := client.GetSatelliteTLEByNoradID(context.Background(), "25544"); err == nil || requests != 2 {
+		t.Errorf("expected failure after 2 requests, got %v after %d", err, requests)
+	}
+}
+
+func TestClientContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.BaseURL = server.URL
+	client.RetryBackoff = time.Hour
+	client.DownloadDir = t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.GetSatelliteTLEByNoradID(ctx, "25544")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline error, got %v", err)
+	}
+}
+
+func TestDownloadTLEsErrorResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"no GP data", http.StatusOK, "No GP data found\n", ErrNotFound},
+		{"empty body", http.StatusOK, "", ErrNotFound},
+		{"not found", http.StatusNotFound, "Not Found", ErrNotFound},
+		{"forbidden", http.StatusForbidden, "Forbidden", ErrRateLimited},
+		{"too many requests", http.StatusTooManyRequests, "", ErrRateLimited},
+		{"server error", http.StatusInternalServerError, "oops", ErrServer},
+		{"malformed", http.StatusOK, "<html>maintenance</html>\n", ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient()
+			client.BaseURL = server.URL
+			client.MaxRetries = 0
+			client.DownloadDir = t.TempDir()
+
+			_, err := client.GetSatelliteTLEByNoradID(context.Background(), "99999")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if _, cached := readCacheEntry(filepath.Join(client.DownloadDir, "99999.tle"), client.gpURL(url.Values{"CATNR": {"99999"}})); cached {
+				t.Error("error response was cached")
+			}
+		})
+	}
+
+	var respErr *ResponseError
+	err := statusError("http://example.com", http.StatusBadRequest, []byte("bad query\nmore"))
+	if !errors.As(err, &respErr) || respErr.Err != nil || respErr.Message != "bad query" {
+		t.Errorf("unexpected error for 400: %#v", err)
+	}
+}
+
+func TestGetSatelliteGroupTLEsUnknownGroup(t *testing.T) {
+	_, err := NewClient().GetSatelliteGroupTLEs(context.Background(), "Nope", CelestrakConfig{})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestReadCelestrakConfigUserGroups(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	userConfig := `
+satellite_groups:
+  - name: space stations
+    url: https://example.com/stations.txt
+  - name: Our Fleet
+    norad_ids: ["25544", "A0001"]
+  - name: Local
+    file: local.tle
+`
+	if err := os.MkdirAll(filepath.Join(dir, "tlego"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tlego", "satellite_groups.yaml"), []byte(userConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := ReadCelestrakConfig()
+	if err != nil {
+		t.Fatalf("ReadCelestrakConfig() error = %v", err)
+	}
+	if len(config.SatelliteGroups) != 46 {
+		t.Errorf("Expected 46 satellite groups, got %d", len(config.SatelliteGroups))
+	}
+	if group, _ := config.Group("Space Stations"); group.URL != "https://example.com/stations.txt" {
+		t.Errorf("built-in group not overridden: %+v", group)
+	}
+	if group, _ := config.Group("local"); group.File != filepath.Join(dir, "tlego", "local.tle") {
+		t.Errorf("relative file not resolved: %q", group.File)
+	}
+
+	CONFIG_FILE = filepath.Join(dir, "missing.yaml")
+	defer func() { CONFIG_FILE = "" }()
+	if _, err := ReadCelestrakConfig(); err == nil {
+		t.Error("expected an error for a missing --config file")
+	}
+
+	if _, err := ParseCelestrakConfig([]byte("satellite_groups:\n  - name: Both\n    url: x\n    file: y\n")); err == nil {
+		t.Error("expected an error for a group with two sources")
+	}
+}
+
+func TestGetSatelliteGroupTLEsLocalGroups(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "fleet.txt")
+	if err := os.WriteFile(file, []byte(testTLE), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := CelestrakConfig{SatelliteGroups: []SatelliteGroup{
+		{Name: "File", File: file},
+		{Name: "IDs", NoradIDs: []string{"25544", "25544"}},
+	}}
+
+	client := NewClient()
+	client.BaseURL = server.URL
+	client.DownloadDir = t.TempDir()
+
+	tles, err := client.GetSatelliteGroupTLEs(context.Background(), "file", config)
+	if err != nil || len(tles) != 1 || tles[0].NoradID != "25544" {
+		t.Errorf("file group: got %d TLEs, error %v", len(tles), err)
+	}
+	tles, err = client.GetSatelliteGroupTLEs(context.Background(), "IDs", config)
+	if err != nil || len(tles) != 2 {
+		t.Errorf("NORAD ID group: got %d TLEs, error %v", len(tles), err)
+	}
+}
+
+func TestClientQueries(t *testing.T) {
+	var paths, queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("CATNR") == "99999" {
+			w.Write([]byte("No GP data found"))
+			return
+		}
+		w.Write([]byte(testTLE))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.BaseURL = server.URL
+	client.DownloadDir = t.TempDir()
+	ctx := context.Background()
+
+	if tles, err := client.GetSatelliteTLEsByIntlDesignator(ctx, "1998-067a"); err != nil || len(tles) != 1 {
+		t.Errorf("GetSatelliteTLEsByIntlDesignator() = %d TLEs, %v", len(tles), err)
+	}
+	if _, err := client.GetSatelliteTLEsByIntlDesignator(ctx, "98067A"); err == nil {
+		t.Error("expected an error for a TLE-style designator")
+	}
+	if tles, err := client.GetSatelliteTLEsByName(ctx, "ISS (ZARYA)"); err != nil || len(tles) != 1 {
+		t.Errorf("GetSatelliteTLEsByName() = %d TLEs, %v", len(tles), err)
+	}
+	if tles, err := client.GetSupplementalTLEs(ctx, "starlink"); err != nil || len(tles) != 1 {
+		t.Errorf("GetSupplementalTLEs() = %d TLEs, %v", len(tles), err)
+	}
+
+	want := []string{
+		"/NORAD/elements/gp.php FORMAT=tle&INTDES=1998-067A",
+		"/NORAD/elements/gp.php FORMAT=tle&NAME=ISS+%28ZARYA%29",
+		"/NORAD/elements/supplemental/sup-gp.php FORMAT=tle&SOURCE=starlink",
+	}
+	for i := range want {
+		if i >= len(paths) || paths[i]+" "+queries[i] != want[i] {
+			t.Errorf("request %d: got %v %v, want %s", i, paths, queries, want[i])
+		}
+	}
+
+	requests := len(queries)
+	tles, err := client.GetSatelliteTLEsByNoradIDs(ctx, []string{"99999", "25544", "25544"})
+	if !errors.Is(err, ErrNotFound) || len(tles) != 2 {
+		t.Errorf("GetSatelliteTLEsByNoradIDs() = %d TLEs, %v; want 2 TLEs and ErrNotFound", len(tles), err)
+	}
+	if len(queries) != requests+1 || queries[requests] != "CATNR=25544%2C99999&FORMAT=tle" {
+		t.Errorf("batch lookup sent %v, want one CATNR list query", queries[requests:])
+	}
+}
+
+func TestCatalogNumberCache(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Write([]byte(testTLE))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.BaseURL = server.URL
+	client.DownloadDir = t.TempDir()
+	client.CacheMaxAge = time.Hour
+	ctx := context.Background()
+
+	// a padded ID is the same satellite, and the same cache file, as a batch of one
+	if _, err := client.GetSatelliteTLEByNoradID(ctx, "025544"); err != nil {
+		t.Fatal(err)
+	}
+	if tles, err := client.GetSatelliteTLEsByNoradIDs(ctx, []string{"25544"}); err != nil || len(tles) != 1 {
+		t.Fatalf("GetSatelliteTLEsByNoradIDs() = %d TLEs, %v", len(tles), err)
+	}
+	if len(queries) != 1 || queries[0] != "CATNR=25544&FORMAT=tle" {
+		t.Errorf("sent %v, want one query for 25544", queries)
+	}
+	if _, err := client.GetSatelliteTLEByNoradID(ctx, "ISS"); err == nil {
+		t.Error("expected an error for an invalid NORAD ID")
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	const css = `CSS (TIANHE)
+1 48274U 21035A   24057.50000000  .00020000  00000-0  22000-3 0  9990
+2 48274  41.4700 100.0000 0005000  90.0000 270.0000 15.60000000150000
+`
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		switch r.URL.Query().Get("GROUP") {
+		case "stations":
+			w.Write([]byte(testTLE + css))
+		case "broken":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte(testTLE))
+		}
+	}))
+	defer server.Close()
+
+	config := CelestrakConfig{}
+	for _, group := range []string{"stations", "visual", "amateur", "broken"} {
+		config.SatelliteGroups = append(config.SatelliteGroups, SatelliteGroup{
+			Name: group, URL: server.URL + "?GROUP=" + group + "&FORMAT=tle",
+		})
+	}
+
+	client := NewClient()
+	client.DownloadDir = t.TempDir()
+	client.MaxRetries = 0
+	client.Workers = 2
+
+	catalog, err := client.Catalog(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Catalog() error = %v", err)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("%d requests in flight, want at most 2", maxInFlight)
+	}
+	if len(catalog.Satellites) != 2 || len(catalog.FailedGroups) != 1 {
+		t.Fatalf("got %d satellites and failed groups %v", len(catalog.Satellites), catalog.FailedGroups)
+	}
+	iss, ok := catalog.Lookup(25544)
+	if !ok || strings.Join(iss.Groups, ",") != "stations,visual,amateur" {
+		t.Errorf("ISS groups = %v", iss.Groups)
+	}
+	if matches := catalog.SearchByName("tianhe"); len(matches) != 1 || matches[0].TLE.NoradID != "48274" {
+		t.Errorf("SearchByName() = %+v", matches)
+	}
+
+	// an index with failed groups is rebuilt, a complete one is reused
+	config.SatelliteGroups = config.SatelliteGroups[:3]
+	built, err := client.Catalog(context.Background(), config)
+	if err != nil || !built.BuiltAt.After(catalog.BuiltAt) {
+		t.Fatalf("expected a rebuilt catalog, got %v", err)
+	}
+	catalog, err = client.Catalog(context.Background(), config)
+	if err != nil || !catalog.BuiltAt.Equal(built.BuiltAt) || len(catalog.Satellites) != 2 {
+		t.Errorf("expected the cached catalog, got one built at %v, %v", catalog.BuiltAt, err)
+	}
+	if entry, _ := catalog.Lookup(48274); entry.TLE.Elements.Inclination != 41.47 {
+		t.Errorf("cached entry not restored: %+v", entry)
+	}
+
+	// editing a group, even without renaming it, rebuilds the index
+	config.SatelliteGroups[2].URL = server.URL + "?GROUP=stations&FORMAT=tle"
+	if catalog, err = client.Catalog(context.Background(), config); err != nil || !catalog.BuiltAt.After(built.BuiltAt) {
+		t.Errorf("expected a rebuilt catalog after a group edit, got %v", err)
+	}
+}
+
+func TestGetSATCAT(t *testing.T) {
+	const satcatCSV = `OBJECT_NAME,OBJECT_ID,NORAD_CAT_ID,OBJECT_TYPE,OPS_STATUS_CODE,OWNER,LAUNCH_DATE,LAUNCH_SITE,DECAY_DATE,PERIOD,INCLINATION,APOGEE,PERIGEE,RCS,DATA_STATUS_CODE,ORBIT_CENTER,ORBIT_TYPE
+ISS (ZARYA),1998-067A,25544,PAY,+,ISS,1998-11-20,TYMSC,,92.87,51.64,422,417,399.0524,,EA,ORB
+`
+	var requests, revalidations int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != satcatPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidations++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(satcatCSV))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.BaseURL = server.URL
+	client.DownloadDir = t.TempDir()
+	lookup := func() {
+		t.Helper()
+		cat, err := client.GetSATCAT(context.Background())
+		if err != nil {
+			t.Fatalf("GetSATCAT() error = %v", err)
+		}
+		if rec, ok := cat.Lookup(25544); !ok || rec.Owner != "ISS" {
+			t.Fatalf("Lookup(25544) = %+v, %v", rec, ok)
+		}
+	}
+
+	lookup()
+	lookup()
+	if requests != 1 {
+		t.Errorf("expected a fresh SATCAT to be served from cache, got %d requests", requests)
+	}
+	client.SATCATMaxAge = 0
+	lookup()
+	if requests != 2 || revalidations != 1 {
+		t.Errorf("expected one conditional request, got %d requests and %d revalidations", requests, revalidations)
+	}
+	client.Offline = true
+	lookup()
+	if requests != 2 {
+		t.Errorf("expected no request in offline mode, got %d requests", requests)
+	}
+
+	client.DownloadDir = t.TempDir()
+	if _, err := client.GetSATCAT(context.Background()); !errors.Is(err, ErrNotCached) {
+		t.Errorf("expected ErrNotCached in offline mode, got %v", err)
+	}
+}
@@ -0,0 +1,363 @@
+package celestrak
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/archive"
+	"github.com/Mohammed-Ashour/tlego/pkg/logger"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// DefaultBaseURL is the CelesTrak server queried when Client.BaseURL is empty.
+const DefaultBaseURL = "https://celestrak.org"
+
+// CelesTrak GP data query endpoints.
+const (
+	gpPath    = "/NORAD/elements/gp.php"
+	supGPPath = "/NORAD/elements/supplemental/sup-gp.php"
+)
+
+// DefaultUserAgent identifies tlego to CelesTrak.
+const DefaultUserAgent = "tlego (+https://github.com/Mohammed-Ashour/tlego)"
+
+// Client fetches element sets from CelesTrak. The zero value is not ready to
+// use; start from NewClient and override what you need.
+type Client struct {
+	HTTPClient   *http.Client
+	BaseURL      string
+	UserAgent    string
+	MaxRetries   int           // retries after a 429 or 5xx response
+	RetryBackoff time.Duration // first retry delay, doubled on every attempt
+	DownloadDir  string
+	Format       tle.Format
+	CacheMaxAge  time.Duration
+	Offline      bool
+	Archive      *archive.Archive // records every downloaded element set; nil to disable
+	Workers      int              // groups fetched at once when building a catalog
+	SATCATMaxAge time.Duration    // how long the downloaded SATCAT is reused
+}
+
+// NewClient returns a client configured from the package-level settings
+// (DOWNLOAD_DIR, FORMAT, CACHE_MAX_AGE, SATCAT_MAX_AGE and OFFLINE) and
+// archiving to the default archive, if archive.ARCHIVE_DIR is set.
+func NewClient() *Client {
+	return &Client{
+		HTTPClient:   &http.Client{Timeout: 60 * time.Second},
+		BaseURL:      DefaultBaseURL,
+		UserAgent:    DefaultUserAgent,
+		MaxRetries:   3,
+		RetryBackoff: time.Second,
+		DownloadDir:  DOWNLOAD_DIR,
+		Format:       FORMAT,
+		CacheMaxAge:  CACHE_MAX_AGE,
+		Offline:      OFFLINE,
+		Archive:      archive.Default(),
+		Workers:      4,
+		SATCATMaxAge: SATCAT_MAX_AGE,
+	}
+}
+
+// GetSatelliteTLEByNoradID fetches the current element set of one satellite.
+func (c *Client) GetSatelliteTLEByNoradID(ctx context.Context, noradID string) (tle.TLE, error) {
+	catalogNumber, err := tle.ParseCatalogNumber(noradID)
+	if err != nil {
+		return tle.TLE{}, err
+	}
+	tles, err := c.catalogNumbers(ctx, []int{catalogNumber})
+	if err != nil {
+		return tle.TLE{}, err
+	}
+	if len(tles) == 0 {
+		return tle.TLE{}, fmt.Errorf("NORAD ID %s: %w", noradID, ErrNotFound)
+	}
+	return tles[0], nil
+}
+
+// GetSatelliteGroupTLEs fetches every element set of a group listed in config.
+// Groups backed by a local file are read from it; groups given as a list of
+// NORAD IDs are fetched with GetSatelliteTLEsByNoradIDs.
+func (c *Client) GetSatelliteGroupTLEs(ctx context.Context, groupName string, config CelestrakConfig) ([]tle.TLE, error) {
+	group, ok := config.Group(groupName)
+	if !ok {
+		return []tle.TLE{}, fmt.Errorf("group %q: %w", groupName, ErrNotFound)
+	}
+
+	switch {
+	case group.File != "":
+		return readGroupFile(group.File)
+	case len(group.NoradIDs) > 0:
+		tles, err := c.GetSatelliteTLEsByNoradIDs(ctx, group.NoradIDs)
+		if err != nil {
+			return tles, fmt.Errorf("group %q: %w", group.Name, err)
+		}
+		return tles, nil
+	default:
+		filename := filepath.Join(c.DownloadDir, groupFileName(group.Name)+"."+string(c.format()))
+		return c.DownloadTLEs(ctx, group.URL, filename)
+	}
+}
+
+// groupFileName turns a group name into a file name, replacing the path
+// separators some names contain (e.g. "WAAS/EGNOS/MSAS").
+func groupFileName(name string) string {
+	return strings.NewReplacer("/", "_", `\`, "_").Replace(name)
+}
+
+// readGroupFile reads a local element set file, guessing its format from the
+// extension.
+func readGroupFile(filename string) ([]tle.TLE, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return []tle.TLE{}, err
+	}
+	defer file.Close()
+	tles, err := tle.ReadFormat(file, tle.FormatOf(filename))
+	if err != nil {
+		return []tle.TLE{}, fmt.Errorf("%s: %w", filename, err)
+	}
+	return tles, nil
+}
+
+// DownloadTLEs fetches the element sets at url, caching the response in filename.
+// A cached response younger than CacheMaxAge is served without a request;
+// older ones are revalidated with If-None-Match / If-Modified-Since.
+// Error responses are never cached; they are reported as a *ResponseError or
+// an error wrapping ErrNotFound or ErrMalformed.
+func (c *Client) DownloadTLEs(ctx context.Context, url string, filename string) ([]tle.TLE, error) {
+	url, format := c.applyFormat(url)
+	var tles []tle.TLE
+	fetched, err := c.download(ctx, url, filename, c.CacheMaxAge, func(r io.Reader) (int, error) {
+		var err error
+		tles, err = tle.ReadFormat(r, format)
+		return len(tles), err
+	})
+	if err != nil {
+		return []tle.TLE{}, err
+	}
+	if fetched && c.Archive != nil {
+		if _, err := c.Archive.Add(tles); err != nil {
+			logger.Warn("Failed to archive TLEs", "dir", c.Archive.Dir, "error", err)
+		}
+	}
+	return tles, nil
+}
+
+// download fetches url through the cache in filename, following the policy
+// DownloadTLEs describes with maxAge, and hands the cached copy or the
+// response to decode, which returns how many records it read. A response is
+// only cached once it decoded into at least one record. fetched reports
+// whether a new response was decoded.
+func (c *Client) download(ctx context.Context, url, filename string, maxAge time.Duration, decode func(io.Reader) (int, error)) (fetched bool, err error) {
+	if err := ensureDownloadDir(filepath.Dir(filename)); err != nil {
+		return false, err
+	}
+
+	entry, cached := readCacheEntry(filename, url)
+	if c.Offline {
+		if !cached {
+			return false, fmt.Errorf("%s: %w", url, ErrNotCached)
+		}
+		return false, readCached(filename, decode)
+	}
+	if cached && entry.fresh(time.Now(), maxAge) {
+		logger.Debug("Serving from cache", "file", filename, "fetched_at", entry.FetchedAt)
+		return false, readCached(filename, decode)
+	}
+
+	header := http.Header{}
+	if cached {
+		if entry.ETag != "" {
+			header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := c.get(ctx, url, header)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if cached && resp.StatusCode == http.StatusNotModified {
+		entry.FetchedAt = time.Now()
+		if err := writeCacheEntry(filename, entry); err != nil {
+			logger.Warn("Failed to update cache metadata", "file", filename, "error", err)
+		}
+		return false, readCached(filename, decode)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := statusError(url, resp.StatusCode, body)
+		logger.Error("CelesTrak request failed", "url", url, "status", resp.StatusCode)
+		return false, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".tmp*")
+	if err != nil {
+		logger.Error("Failed to create file", "error", err)
+		return false, err
+	}
+
+	// Keep a copy of the response on disk while decoding it straight off the body,
+	// and only replace the cached copy once it decoded into at least one record
+	head := &prefixWriter{limit: 1024}
+	n, err := decode(io.TeeReader(resp.Body, io.MultiWriter(tmp, head)))
+	if closeErr := tmp.Close(); closeErr != nil {
+		os.Remove(tmp.Name())
+		return false, closeErr
+	}
+	if err != nil || n == 0 {
+		os.Remove(tmp.Name())
+		err = payloadError(url, head.buf, err)
+		logger.Error("Failed to read response", "url", url, "error", err)
+		return false, err
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		os.Remove(tmp.Name())
+		logger.Error("Error writing to file", "error", err)
+		return false, err
+	}
+
+	err = writeCacheEntry(filename, cacheEntry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+	})
+	if err != nil {
+		logger.Warn("Failed to write cache metadata", "file", filename, "error", err)
+	}
+	return true, nil
+}
+
+// get performs a GET request, retrying with exponential backoff while the
+// server answers 429 Too Many Requests or a 5xx status. A Retry-After header
+// given in seconds overrides the backoff delay. Once the retries are used up
+// the last response is returned for the caller to report.
+func (c *Client) get(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	backoff := c.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if !retryable(resp.StatusCode) {
+			return resp, nil
+		}
+		if attempt >= c.MaxRetries {
+			logger.Warn("Giving up on CelesTrak request", "url", url, "attempts", attempt+1)
+			return resp, nil
+		}
+
+		delay := backoff
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		}
+		resp.Body.Close()
+		logger.Warn("Retrying CelesTrak request", "url", url, "status", resp.StatusCode, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		backoff *= 2
+	}
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// gpURL builds a GP query against the client's base URL in its format.
+func (c *Client) gpURL(query neturl.Values) string {
+	return c.queryURL(gpPath, query)
+}
+
+// queryURL builds a query of a CelesTrak endpoint in the client's format.
+func (c *Client) queryURL(path string, query neturl.Values) string {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	query.Set("FORMAT", string(c.format()))
+	return baseURL + path + "?" + query.Encode()
+}
+
+func (c *Client) format() tle.Format {
+	if c.Format == "" {
+		return tle.FormatTLE
+	}
+	return c.Format
+}
+
+// applyFormat rewrites the FORMAT query parameter of a CelesTrak URL to the
+// client's format and returns the format the response will be in. URLs
+// without a FORMAT parameter are left alone and assumed to serve TLEs.
+func (c *Client) applyFormat(rawURL string) (string, tle.Format) {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return rawURL, tle.FormatTLE
+	}
+	query := u.Query()
+	if !query.Has("FORMAT") {
+		return rawURL, tle.FormatTLE
+	}
+	query.Set("FORMAT", string(c.format()))
+	u.RawQuery = query.Encode()
+	return u.String(), c.format()
+}
+
+// prefixWriter keeps the first limit bytes written to it, so an unusable
+// response can be described without buffering all of it.
+type prefixWriter struct {
+	buf   []byte
+	limit int
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	if room := w.limit - len(w.buf); room > 0 {
+		w.buf = append(w.buf, p[:min(room, len(p))]...)
+	}
+	return len(p), nil
+}
+
+// readCached decodes a previously downloaded response.
+func readCached(filename string, decode func(io.Reader) (int, error)) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := decode(file); err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package celestrak
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfig is the built-in list of CelesTrak groups.
+//
+//go:embed satellite_groups.yaml
+var defaultConfig []byte
+
+// CONFIG_FILE is the user groups file layered over the built-in groups. When
+// empty, satellite_groups.yaml in the tlego directory of the user config dir
+// ($XDG_CONFIG_HOME/tlego on Linux) is used if it exists.
+var CONFIG_FILE = ""
+
+// SatelliteGroup is a named set of satellites. Exactly one of URL, File and
+// NoradIDs says where its element sets come from.
+type SatelliteGroup struct {
+	Name     string   `yaml:"name"`
+	URL      string   `yaml:"url,omitempty"`
+	File     string   `yaml:"file,omitempty"`      // local TLE or OMM file, format taken from the extension
+	NoradIDs []string `yaml:"norad_ids,omitempty"` // fetched from CelesTrak in CATNR queries of up to 100 IDs, see GetSatelliteTLEsByNoradIDs
+}
+
+type CelestrakConfig struct {
+	SatelliteGroups []SatelliteGroup `yaml:"satellite_groups"`
+	Metadata        struct {
+		Source      string `yaml:"source"`
+		URL         string `yaml:"url"`
+		Format      string `yaml:"format"`
+		Provider    string `yaml:"provider"`
+		DataSource  string `yaml:"data_source"`
+		LastUpdated string `yaml:"last_updated"`
+	} `yaml:"metadata"`
+}
+
+// ReadCelestrakConfig returns the built-in groups with the user groups file
+// applied on top: user groups replace built-in groups of the same name and
+// are otherwise appended.
+func ReadCelestrakConfig() (CelestrakConfig, error) {
+	config, err := ParseCelestrakConfig(defaultConfig)
+	if err != nil {
+		logger.Error("Failed to unmarshal yaml", "error", err)
+		return CelestrakConfig{}, err
+	}
+
+	fp, err := userConfigPath()
+	if err != nil {
+		return config, nil
+	}
+	user, err := ReadCelestrakConfigFile(fp)
+	if errors.Is(err, os.ErrNotExist) && CONFIG_FILE == "" {
+		return config, nil
+	}
+	if err != nil {
+		return CelestrakConfig{}, err
+	}
+	config.Merge(user)
+	return config, nil
+}
+
+// ReadCelestrakConfigFile reads a groups file. Relative file paths of its
+// groups are resolved against the directory the file is in.
+func ReadCelestrakConfigFile(fp string) (CelestrakConfig, error) {
+	logger.Info("Reading file", "file", fp)
+	data, err := os.ReadFile(fp)
+	if err != nil {
+		return CelestrakConfig{}, err
+	}
+	config, err := ParseCelestrakConfig(data)
+	if err != nil {
+		return CelestrakConfig{}, fmt.Errorf("%s: %w", fp, err)
+	}
+	for i, group := range config.SatelliteGroups {
+		if group.File != "" && !filepath.IsAbs(group.File) {
+			config.SatelliteGroups[i].File = filepath.Join(filepath.Dir(fp), group.File)
+		}
+	}
+	return config, nil
+}
+
+// ParseCelestrakConfig decodes and checks a groups file.
+func ParseCelestrakConfig(data []byte) (CelestrakConfig, error) {
+	var config CelestrakConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return CelestrakConfig{}, err
+	}
+	for _, group := range config.SatelliteGroups {
+		if err := group.validate(); err != nil {
+			return CelestrakConfig{}, err
+		}
+	}
+	return config, nil
+}
+
+// Merge adds the groups of other to c, replacing groups with the same name.
+func (c *CelestrakConfig) Merge(other CelestrakConfig) {
+	for _, group := range other.SatelliteGroups {
+		if i := c.groupIndex(group.Name); i >= 0 {
+			c.SatelliteGroups[i] = group
+		} else {
+			c.SatelliteGroups = append(c.SatelliteGroups, group)
+		}
+	}
+}
+
+// Group looks up a group by name, ignoring case.
+func (c CelestrakConfig) Group(name string) (SatelliteGroup, bool) {
+	if i := c.groupIndex(name); i >= 0 {
+		return c.SatelliteGroups[i], true
+	}
+	return SatelliteGroup{}, false
+}
+
+func (c CelestrakConfig) groupIndex(name string) int {
+	for i, group := range c.SatelliteGroups {
+		if strings.EqualFold(group.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g SatelliteGroup) validate() error {
+	if g.Name == "" {
+		return errors.New("satellite group without a name")
+	}
+	sources := 0
+	for _, set := range []bool{g.URL != "", g.File != "", len(g.NoradIDs) > 0} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("satellite group %q: exactly one of url, file and norad_ids must be set", g.Name)
+	}
+	return nil
+}
+
+// userConfigPath returns CONFIG_FILE, or the default user groups file.
+func userConfigPath() (string, error) {
+	if CONFIG_FILE != "" {
+		return CONFIG_FILE, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tlego", "satellite_groups.yaml"), nil
+}
@@ -0,0 +1,91 @@
+package celestrak
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors describing why a CelesTrak query failed. Use errors.Is to
+// test for them; the returned errors carry the details.
+var (
+	ErrNotFound    = errors.New("no GP data found")
+	ErrRateLimited = errors.New("rate limited by CelesTrak")
+	ErrServer      = errors.New("CelesTrak server error")
+	ErrMalformed   = errors.New("malformed CelesTrak response")
+)
+
+// noGPDataMessage is the plain-text body CelesTrak answers with, using
+// status 200, when a query matches nothing.
+var noGPDataMessage = []byte("No GP data found")
+
+// ResponseError is returned when CelesTrak answers with an unusable response.
+// Err is one of the sentinel errors, or nil for unexpected status codes.
+type ResponseError struct {
+	URL        string
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+func (e *ResponseError) Error() string {
+	msg := fmt.Sprintf("%s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// statusError classifies a non-success HTTP status. body is the start of the
+// response body, used as the error message.
+func statusError(url string, status int, body []byte) error {
+	err := &ResponseError{
+		URL:        url,
+		StatusCode: status,
+		Message:    firstLine(body),
+	}
+	switch {
+	case status == http.StatusNotFound:
+		err.Err = ErrNotFound
+	case status == http.StatusTooManyRequests || status == http.StatusForbidden:
+		// CelesTrak answers 403 to clients blocked for downloading too often
+		err.Err = ErrRateLimited
+	case status >= 500:
+		err.Err = ErrServer
+	}
+	return err
+}
+
+// payloadError classifies a response body that did not decode into any
+// element sets.
+func payloadError(url string, body []byte, decodeErr error) error {
+	switch {
+	case bytes.Contains(body, noGPDataMessage):
+		return &ResponseError{URL: url, StatusCode: http.StatusOK, Message: firstLine(body), Err: ErrNotFound}
+	case decodeErr != nil:
+		return fmt.Errorf("%s: %w: %v", url, ErrMalformed, decodeErr)
+	case len(bytes.TrimSpace(body)) == 0:
+		return fmt.Errorf("%s: %w: empty response", url, ErrNotFound)
+	default:
+		return fmt.Errorf("%s: %w: no element sets in %q", url, ErrMalformed, firstLine(body))
+	}
+}
+
+func firstLine(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if i := bytes.IndexByte(body, '\n'); i >= 0 {
+		body = body[:i]
+	}
+	if len(body) > 200 {
+		body = body[:200]
+	}
+	return string(bytes.TrimSpace(body))
+}
@@ -0,0 +1,141 @@
+package celestrak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	neturl "net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// intdesPattern matches an international designator as CelesTrak expects it:
+// launch year, launch number and an optional piece, e.g. 1998-067A. Without
+// the piece every object of the launch is returned.
+var intdesPattern = regexp.MustCompile(`^[0-9]{4}-[0-9]{3}[A-Z]{0,3}$`)
+
+// catnrBatch is how many catalog numbers go into one GP query; CelesTrak
+// accepts them as a comma-separated CATNR list.
+const catnrBatch = 100
+
+// GetSatelliteTLEsByNoradIDs fetches the element sets of several satellites,
+// in the order given, batching the IDs into as few queries as possible. IDs
+// CelesTrak has no data for are skipped and reported together in an error
+// wrapping ErrNotFound, returned with the element sets that were found; any
+// other failure stops the lookup.
+func (c *Client) GetSatelliteTLEsByNoradIDs(ctx context.Context, noradIDs []string) ([]tle.TLE, error) {
+	numbers := make([]int, 0, len(noradIDs))
+	for _, id := range noradIDs {
+		catalogNumber, err := tle.ParseCatalogNumber(id)
+		if err != nil {
+			return []tle.TLE{}, err
+		}
+		numbers = append(numbers, catalogNumber)
+	}
+
+	unique := slices.Clone(numbers)
+	slices.Sort(unique)
+	unique = slices.Compact(unique)
+	found := make(map[int]tle.TLE, len(unique))
+	for start := 0; start < len(unique); start += catnrBatch {
+		batch := unique[start:min(start+catnrBatch, len(unique))]
+		tles, err := c.catalogNumbers(ctx, batch)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return []tle.TLE{}, err
+		}
+		for _, t := range tles {
+			found[t.Elements.CatalogNumber] = t
+		}
+	}
+
+	tles := make([]tle.TLE, 0, len(noradIDs))
+	var missing []string
+	for i, id := range noradIDs {
+		t, ok := found[numbers[i]]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		tles = append(tles, t)
+	}
+	if len(missing) > 0 {
+		return tles, fmt.Errorf("NORAD IDs %s: %w", strings.Join(missing, ", "), ErrNotFound)
+	}
+	return tles, nil
+}
+
+// catalogNumbers runs one CATNR query for a batch of catalog numbers.
+func (c *Client) catalogNumbers(ctx context.Context, numbers []int) ([]tle.TLE, error) {
+	url, filename := c.catnrQuery(numbers)
+	return c.DownloadTLEs(ctx, url, filename)
+}
+
+// catnrQuery returns the GP query URL and cache file for a batch of catalog
+// numbers. A single number is cached under its decimal form, so every
+// spelling of it, with leading zeros or in Alpha-5, shares one file; a list
+// is cached under a hash of it.
+func (c *Client) catnrQuery(numbers []int) (url, filename string) {
+	ids := make([]string, len(numbers))
+	for i, n := range numbers {
+		ids[i] = strconv.Itoa(n)
+	}
+	list := strings.Join(ids, ",")
+	name := list
+	if len(numbers) > 1 {
+		h := fnv.New64a()
+		h.Write([]byte(list))
+		name = fmt.Sprintf("CATNR_%016x", h.Sum64())
+	}
+	return c.gpURL(neturl.Values{"CATNR": {list}}), filepath.Join(c.DownloadDir, name+"."+string(c.format()))
+}
+
+// GetSatelliteTLEsByIntlDesignator fetches the element sets of one object
+// (1998-067A) or of every object of a launch (1998-067).
+func (c *Client) GetSatelliteTLEsByIntlDesignator(ctx context.Context, intdes string) ([]tle.TLE, error) {
+	intdes = strings.ToUpper(strings.TrimSpace(intdes))
+	if !intdesPattern.MatchString(intdes) {
+		return []tle.TLE{}, fmt.Errorf("invalid international designator %q, expected YYYY-NNN or YYYY-NNNA", intdes)
+	}
+	return c.query(ctx, gpPath, "INTDES", intdes)
+}
+
+// GetSatelliteTLEsByName fetches the element sets of every satellite whose
+// name contains name, ignoring case.
+func (c *Client) GetSatelliteTLEsByName(ctx context.Context, name string) ([]tle.TLE, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return []tle.TLE{}, errors.New("empty satellite name")
+	}
+	return c.query(ctx, gpPath, "NAME", name)
+}
+
+// GetSupplementalTLEs fetches the supplemental GP data CelesTrak derives from
+// an operator's ephemerides, e.g. source "starlink" or "oneweb".
+func (c *Client) GetSupplementalTLEs(ctx context.Context, source string) ([]tle.TLE, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return []tle.TLE{}, errors.New("empty supplemental GP source")
+	}
+	return c.query(ctx, supGPPath, "SOURCE", source)
+}
+
+// query runs a single-parameter query and caches the response under a file
+// named after it.
+func (c *Client) query(ctx context.Context, path, key, value string) ([]tle.TLE, error) {
+	url := c.queryURL(path, neturl.Values{key: {value}})
+	prefix := key
+	if path == supGPPath {
+		prefix = "SUP_" + key
+	}
+	filename := filepath.Join(c.DownloadDir, prefix+"_"+groupFileName(value)+"."+string(c.format()))
+	return c.DownloadTLEs(ctx, url, filename)
+}
@@ -0,0 +1,42 @@
+package celestrak
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/satcat"
+)
+
+// satcatPath is the full SATCAT in CSV form.
+const satcatPath = "/pub/satcat.csv"
+
+// satcatFile is the name of the cached SATCAT in indexDir, out of the way of
+// the element set files.
+const satcatFile = "satcat.csv"
+
+// SATCAT_MAX_AGE is the default Client.SATCATMaxAge. The catalog is large and
+// changes slowly, so it is kept longer than GP data.
+var SATCAT_MAX_AGE = 24 * time.Hour
+
+// GetSATCAT returns the satellite catalog, downloaded once it is older than
+// SATCATMaxAge and cached in the download directory like GP data. In offline
+// mode only the cached copy is read.
+func (c *Client) GetSATCAT(ctx context.Context) (*satcat.Catalog, error) {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	filename := filepath.Join(c.DownloadDir, indexDir, satcatFile)
+	var records []satcat.Record
+	_, err := c.download(ctx, baseURL+satcatPath, filename, c.SATCATMaxAge, func(r io.Reader) (int, error) {
+		var err error
+		records, err = satcat.Read(r)
+		return len(records), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return satcat.NewCatalog(records), nil
+}
@@ -0,0 +1,84 @@
+// Package doppler corrects radio frequencies for the Doppler shift caused by
+// a satellite's motion relative to a ground station.
+package doppler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+)
+
+// speedOfLight in km/s, to match range rates.
+const speedOfLight = 299792.458
+
+// Frequencies are the nominal link frequencies in Hz: what the satellite
+// transmits (Downlink) and what it listens on (Uplink). Zero means unused.
+type Frequencies struct {
+	Downlink float64
+	Uplink   float64
+}
+
+// Downlink returns the frequency a station receives a transmission on f Hz,
+// given the range rate in km/s (positive while receding). Range rates are
+// tiny next to the speed of light, so the first-order shift is used.
+func Downlink(f, rangeRate float64) float64 {
+	return f * (1 - rangeRate/speedOfLight)
+}
+
+// Uplink returns the frequency a station must transmit on for the satellite
+// to receive f Hz, given the range rate in km/s.
+func Uplink(f, rangeRate float64) float64 {
+	return f / (1 - rangeRate/speedOfLight)
+}
+
+// Sample is a look at the satellite with the corrected frequencies.
+type Sample struct {
+	observer.Sample
+	Downlink float64 // Hz to tune the receiver to; zero when unused
+	Uplink   float64 // Hz to transmit on; zero when unused
+}
+
+// Correct returns the corrected frequencies for a look.
+func Correct(f Frequencies, s observer.Sample) Sample {
+	out := Sample{Sample: s}
+	if f.Downlink != 0 {
+		out.Downlink = Downlink(f.Downlink, s.RangeRate)
+	}
+	if f.Uplink != 0 {
+		out.Uplink = Uplink(f.Uplink, s.RangeRate)
+	}
+	return out
+}
+
+// Series corrects f over a series of looks, such as observer.Track returns.
+func Series(f Frequencies, samples []observer.Sample) []Sample {
+	out := make([]Sample, 0, len(samples))
+	for _, s := range samples {
+		out = append(out, Correct(f, s))
+	}
+	return out
+}
+
+// ParseFrequency reads a frequency in Hz from a number with an optional unit:
+// Hz, kHz, MHz or GHz, case-insensitive. Bare numbers are in MHz, as radio
+// frequencies are usually quoted, e.g. "145.8" or "437.525MHz".
+func ParseFrequency(s string) (float64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	scale := 1e6
+	for _, unit := range []struct {
+		suffix string
+		scale  float64
+	}{{"ghz", 1e9}, {"mhz", 1e6}, {"khz", 1e3}, {"hz", 1}} {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, scale = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), unit.scale
+			break
+		}
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f <= 0 {
+		return 0, fmt.Errorf("invalid frequency %q", s)
+	}
+	return f * scale, nil
+}
@@ -0,0 +1,83 @@
+package doppler
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+)
+
+func TestShift(t *testing.T) {
+	// a LEO satellite approaching at 7 km/s raises 145.8 MHz by about 3.4 kHz
+	got := Downlink(145.8e6, -7) - 145.8e6
+	if math.Abs(got-3404.4) > 0.5 {
+		t.Errorf("downlink shift = %.1f Hz, want 3404.4", got)
+	}
+	// the uplink is pre-shifted the other way so the satellite hears f
+	if up := Uplink(435e6, -7); up >= 435e6 || math.Abs(Downlink(up, -7)-435e6) > 1e-3 {
+		t.Errorf("Uplink() = %f Hz", up)
+	}
+	if Downlink(100e6, 0) != 100e6 {
+		t.Error("no range rate should mean no shift")
+	}
+}
+
+func TestParseFrequency(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"145.8", 145.8e6},
+		{"437.525MHz", 437.525e6},
+		{"2.4 GHz", 2.4e9},
+		{"10489.75khz", 10489.75e3},
+		{"145800000Hz", 145.8e6},
+	}
+	for _, tt := range tests {
+		got, err := ParseFrequency(tt.in)
+		if err != nil || math.Abs(got-tt.want) > 1e-3 {
+			t.Errorf("ParseFrequency(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "MHz", "-5", "fast"} {
+		if _, err := ParseFrequency(in); err == nil {
+			t.Errorf("ParseFrequency(%q) succeeded", in)
+		}
+	}
+}
+
+func TestSeriesOutput(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	looks := []observer.Sample{
+		{Time: start, Look: observer.Look{Elevation: 10, RangeRate: -6}},
+		{Time: start.Add(time.Minute), Look: observer.Look{Elevation: 40, RangeRate: 0}},
+		{Time: start.Add(2 * time.Minute), Look: observer.Look{Elevation: 10, RangeRate: 6}},
+	}
+	f := Frequencies{Downlink: 145.8e6}
+	samples := Series(f, looks)
+	if !(samples[0].Downlink > f.Downlink && samples[1].Downlink == f.Downlink && samples[2].Downlink < f.Downlink) {
+		t.Errorf("downlink should fall through the pass: %+v", samples)
+	}
+	if samples[0].Uplink != 0 {
+		t.Errorf("unused uplink corrected to %v", samples[0].Uplink)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "csv", f, samples); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.HasSuffix(lines[0], "downlink_hz,downlink_shift_hz") {
+		t.Errorf("Write(csv) = %s", buf.String())
+	}
+	buf.Reset()
+	if err := Write(&buf, "json", f, samples); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "uplink") || !strings.Contains(buf.String(), `"downlink_shift_hz": 0`) {
+		t.Errorf("Write(json) = %s", buf.String())
+	}
+}
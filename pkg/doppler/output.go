@@ -0,0 +1,81 @@
+package doppler
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/output"
+)
+
+// sampleJSON is the JSON form of a Sample. Unused links are left out.
+type sampleJSON struct {
+	Time          string   `json:"time"`
+	Azimuth       float64  `json:"azimuth_deg"`
+	Elevation     float64  `json:"elevation_deg"`
+	Range         float64  `json:"range_km"`
+	RangeRate     float64  `json:"range_rate_km_s"`
+	Downlink      *float64 `json:"downlink_hz,omitempty"`
+	DownlinkShift *float64 `json:"downlink_shift_hz,omitempty"`
+	Uplink        *float64 `json:"uplink_hz,omitempty"`
+	UplinkShift   *float64 `json:"uplink_shift_hz,omitempty"`
+}
+
+func toJSON(f Frequencies, s Sample) sampleJSON {
+	out := sampleJSON{
+		Time:      s.Time.UTC().Format(output.TimeFormat),
+		Azimuth:   s.Azimuth,
+		Elevation: s.Elevation,
+		Range:     s.Range,
+		RangeRate: s.RangeRate,
+	}
+	if f.Downlink != 0 {
+		shift := s.Downlink - f.Downlink
+		out.Downlink, out.DownlinkShift = &s.Downlink, &shift
+	}
+	if f.Uplink != 0 {
+		shift := s.Uplink - f.Uplink
+		out.Uplink, out.UplinkShift = &s.Uplink, &shift
+	}
+	return out
+}
+
+// Write writes samples in format: table, json or csv, with columns for the
+// links in use. Tables are in UTC, with frequencies in MHz and shifts in Hz.
+func Write(w io.Writer, format string, f Frequencies, samples []Sample) error {
+	table := []string{"TIME (UTC)", "AZIMUTH", "ELEVATION", "RANGE (km)", "RANGE RATE (km/s)"}
+	header := []string{"time", "azimuth_deg", "elevation_deg", "range_km", "range_rate_km_s"}
+	if f.Downlink != 0 {
+		table = append(table, "DOWNLINK (MHz)", "SHIFT (Hz)")
+		header = append(header, "downlink_hz", "downlink_shift_hz")
+	}
+	if f.Uplink != 0 {
+		table = append(table, "UPLINK (MHz)", "SHIFT (Hz)")
+		header = append(header, "uplink_hz", "uplink_shift_hz")
+	}
+	d := output.Data{Table: [][]string{table}, CSV: [][]string{header}}
+	out := make([]sampleJSON, 0, len(samples))
+	for _, s := range samples {
+		t := s.Time.UTC().Format(output.TimeFormat)
+		row := []string{t,
+			fmt.Sprintf("%.2f°", s.Azimuth), fmt.Sprintf("%.2f°", s.Elevation),
+			fmt.Sprintf("%.1f", s.Range), fmt.Sprintf("%.3f", s.RangeRate),
+		}
+		record := []string{t,
+			output.FormatFloat(s.Azimuth), output.FormatFloat(s.Elevation),
+			output.FormatFloat(s.Range), output.FormatFloat(s.RangeRate),
+		}
+		if f.Downlink != 0 {
+			row = append(row, fmt.Sprintf("%.6f", s.Downlink/1e6), fmt.Sprintf("%+.0f", s.Downlink-f.Downlink))
+			record = append(record, output.FormatFloat(s.Downlink), output.FormatFloat(s.Downlink-f.Downlink))
+		}
+		if f.Uplink != 0 {
+			row = append(row, fmt.Sprintf("%.6f", s.Uplink/1e6), fmt.Sprintf("%+.0f", s.Uplink-f.Uplink))
+			record = append(record, output.FormatFloat(s.Uplink), output.FormatFloat(s.Uplink-f.Uplink))
+		}
+		d.Table = append(d.Table, row)
+		d.CSV = append(d.CSV, record)
+		out = append(out, toJSON(f, s))
+	}
+	d.JSON = out
+	return output.Write(w, format, d)
+}
@@ -0,0 +1,289 @@
+// Package eclipse finds when a satellite passes through the Earth's shadow
+// and tracks the solar beta angle of its orbit.
+package eclipse
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+	"github.com/Mohammed-Ashour/tlego/pkg/sun"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// Defaults for Options.
+const (
+	DefaultStep      = 30 * time.Second
+	DefaultPrecision = 100 * time.Millisecond
+)
+
+// mu is the Earth's gravitational parameter, km^3/s^2.
+const mu = 398600.4418
+
+// j2 is the Earth's oblateness term (WGS84), which turns orbit planes.
+const j2 = 1.08262998905e-3
+
+const deg = math.Pi / 180
+
+// Eclipse is one passage through the Earth's shadow, from entering the
+// penumbra to leaving it. Eclipses already under way at the start of the
+// span, or not over by its end, are cut to the span and flagged.
+type Eclipse struct {
+	Entry    time.Time // into the penumbra
+	Exit     time.Time // out of the penumbra
+	Duration time.Duration
+	// UmbraEntry and UmbraExit bound the full shadow, zero if the satellite
+	// only crossed the penumbra.
+	UmbraEntry    time.Time
+	UmbraExit     time.Time
+	UmbraDuration time.Duration
+	// OrbitFraction is the share of an orbital period in shadow, 0 to 1.
+	OrbitFraction float64
+	// Beta is the solar beta angle at entry, degrees.
+	Beta         float64
+	StartClipped bool
+	EndClipped   bool
+}
+
+// Options tune the search.
+type Options struct {
+	// Step is how often the shadow is sampled. Eclipses shorter than a step
+	// may be missed.
+	Step time.Duration
+	// Precision is how closely entries and exits are located.
+	Precision time.Duration
+	// Period is the orbital period for OrbitFraction, such as the TLE mean
+	// motion gives. If zero the osculating period at entry is used.
+	Period time.Duration
+	// Plane is the mean orbit plane for Beta, such as NewMeanPlane gives. If
+	// nil the osculating plane at entry is used.
+	Plane *MeanPlane
+}
+
+// Find returns the eclipses of the satellite propagated by p between start
+// and end, in time order.
+func Find(p observer.Propagator, start, end time.Time, opts Options) ([]Eclipse, error) {
+	if !end.After(start) {
+		return nil, errors.New("the time span must end after it starts")
+	}
+	if opts.Step <= 0 {
+		opts.Step = DefaultStep
+	}
+	if opts.Precision <= 0 {
+		opts.Precision = DefaultPrecision
+	}
+	f := finder{p: p, opts: opts}
+
+	var times []time.Time
+	var shadows []sun.Shadow
+	for t := start; ; t = t.Add(opts.Step) {
+		if t.After(end) {
+			t = end
+		}
+		s, err := sun.ShadowAt(p, t)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+		shadows = append(shadows, s)
+		if !t.Before(end) {
+			break
+		}
+	}
+	dark := func(s sun.Shadow) bool { return s != sun.Sunlit }
+	umbra := func(s sun.Shadow) bool { return s == sun.Umbra }
+
+	var eclipses []Eclipse
+	var current Eclipse
+	if dark(shadows[0]) {
+		current = Eclipse{Entry: start, StartClipped: true}
+		if umbra(shadows[0]) {
+			current.UmbraEntry = start
+		}
+	}
+	for i := 0; i+1 < len(times); i++ {
+		a, b := shadows[i], shadows[i+1]
+		if !dark(a) && dark(b) {
+			t, err := f.crossing(times[i], times[i+1], dark)
+			if err != nil {
+				return nil, err
+			}
+			current = Eclipse{Entry: t}
+		}
+		if !umbra(a) && umbra(b) {
+			t, err := f.crossing(times[i], times[i+1], umbra)
+			if err != nil {
+				return nil, err
+			}
+			current.UmbraEntry = t
+		}
+		if umbra(a) && !umbra(b) {
+			t, err := f.crossing(times[i], times[i+1], func(s sun.Shadow) bool { return !umbra(s) })
+			if err != nil {
+				return nil, err
+			}
+			current.UmbraExit = t
+		}
+		if dark(a) && !dark(b) {
+			t, err := f.crossing(times[i], times[i+1], func(s sun.Shadow) bool { return !dark(s) })
+			if err != nil {
+				return nil, err
+			}
+			current.Exit = t
+			e, err := f.finish(current)
+			if err != nil {
+				return nil, err
+			}
+			eclipses = append(eclipses, e)
+		}
+	}
+	if last := shadows[len(shadows)-1]; dark(last) {
+		current.Exit, current.EndClipped = end, true
+		if umbra(last) {
+			current.UmbraExit = end
+		}
+		e, err := f.finish(current)
+		if err != nil {
+			return nil, err
+		}
+		eclipses = append(eclipses, e)
+	}
+	return eclipses, nil
+}
+
+type finder struct {
+	p    observer.Propagator
+	opts Options
+}
+
+// crossing bisects [a, b] for the first moment the shadow satisfies after,
+// which it does not at a but does at b.
+func (f finder) crossing(a, b time.Time, after func(sun.Shadow) bool) (time.Time, error) {
+	for b.Sub(a) > f.opts.Precision {
+		mid := a.Add(b.Sub(a) / 2)
+		s, err := sun.ShadowAt(f.p, mid)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if after(s) {
+			b = mid
+		} else {
+			a = mid
+		}
+	}
+	return a.Add(b.Sub(a) / 2), nil
+}
+
+// finish fills in the durations, orbit fraction and beta angle.
+func (f finder) finish(e Eclipse) (Eclipse, error) {
+	e.Duration = e.Exit.Sub(e.Entry)
+	if !e.UmbraEntry.IsZero() && !e.UmbraExit.IsZero() {
+		e.UmbraDuration = e.UmbraExit.Sub(e.UmbraEntry)
+	} else {
+		e.UmbraEntry, e.UmbraExit = time.Time{}, time.Time{}
+	}
+	s, err := f.p.StateAt(e.Entry)
+	if err != nil {
+		return Eclipse{}, err
+	}
+	period := f.opts.Period
+	if period <= 0 {
+		period = Period(s)
+	}
+	e.OrbitFraction = e.Duration.Seconds() / period.Seconds()
+	if f.opts.Plane != nil {
+		e.Beta = f.opts.Plane.Beta(e.Entry)
+	} else {
+		e.Beta = Beta(s, e.Entry)
+	}
+	return e, nil
+}
+
+// Period returns the osculating orbital period of state s.
+func Period(s observer.State) time.Duration {
+	r, v := s.Position.Norm(), s.Velocity.Norm()
+	a := 1 / (2/r - v*v/mu)
+	return time.Duration(2 * math.Pi * math.Sqrt(a*a*a/mu) * float64(time.Second))
+}
+
+// Beta returns the solar beta angle in degrees at t of the orbit plane
+// through state s: the Sun's elevation above the plane, positive on the
+// side the orbit's angular momentum points to. The osculating plane of an
+// SGP4 state wobbles around the mean plane through every orbit;
+// MeanPlane.Beta leaves that out.
+func Beta(s observer.State, t time.Time) float64 {
+	return beta(s.Position.Cross(s.Velocity), t)
+}
+
+// beta returns the Sun's elevation in degrees at t above the plane with
+// the given normal.
+func beta(normal observer.Vector, t time.Time) float64 {
+	toSun := sun.Position(t)
+	return math.Asin(normal.Dot(toSun)/(normal.Norm()*toSun.Norm())) / deg
+}
+
+// MeanPlane is the orbit plane given by mean elements. It keeps its
+// inclination and turns about the Earth's axis at the secular rate J2 drives
+// the ascending node, which is what moves the beta angle over the seasons.
+type MeanPlane struct {
+	Epoch          time.Time
+	Inclination    float64 // degrees
+	RightAscension float64 // of the ascending node at Epoch, degrees
+	NodeRate       float64 // degrees per day, negative for prograde orbits
+}
+
+// NewMeanPlane returns the mean orbit plane of the element set e.
+func NewMeanPlane(e tle.Elements) MeanPlane {
+	n := e.MeanMotion * 2 * math.Pi / 86400 // rad/s
+	a := math.Cbrt(tle.EarthMu / (n * n))
+	ratio := tle.EarthRadius / (a * (1 - e.Eccentricity*e.Eccentricity))
+	rate := -1.5 * n * j2 * ratio * ratio * math.Cos(e.Inclination*deg)
+	return MeanPlane{
+		Epoch:          e.Epoch,
+		Inclination:    e.Inclination,
+		RightAscension: e.RightAscension,
+		NodeRate:       rate * 86400 / deg,
+	}
+}
+
+// Normal returns the unit normal of the plane at t, along the orbit's
+// angular momentum.
+func (m MeanPlane) Normal(t time.Time) observer.Vector {
+	node := (m.RightAscension + m.NodeRate*t.Sub(m.Epoch).Hours()/24) * deg
+	sinI, cosI := math.Sincos(m.Inclination * deg)
+	sinNode, cosNode := math.Sincos(node)
+	return observer.Vector{sinI * sinNode, -sinI * cosNode, cosI}
+}
+
+// Beta returns the solar beta angle of the plane at t in degrees.
+func (m MeanPlane) Beta(t time.Time) float64 {
+	return beta(m.Normal(t), t)
+}
+
+// BetaSample is the beta angle at one moment.
+type BetaSample struct {
+	Time time.Time
+	Beta float64 // degrees
+}
+
+// BetaSeries samples the beta angle of plane from start to end every step,
+// both ends included.
+func BetaSeries(plane MeanPlane, start, end time.Time, step time.Duration) ([]BetaSample, error) {
+	if step <= 0 {
+		return nil, errors.New("the step must be positive")
+	}
+	if end.Before(start) {
+		return nil, errors.New("the time span must end after it starts")
+	}
+	var samples []BetaSample
+	for t := start; ; t = t.Add(step) {
+		if t.After(end) {
+			t = end
+		}
+		samples = append(samples, BetaSample{Time: t, Beta: plane.Beta(t)})
+		if !t.Before(end) {
+			return samples, nil
+		}
+	}
+}
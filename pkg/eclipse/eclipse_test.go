@@ -0,0 +1,176 @@
+package eclipse
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+	"github.com/Mohammed-Ashour/tlego/pkg/sun"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// circular is a satellite on a circular equatorial orbit.
+type circular struct {
+	epoch  time.Time
+	radius float64 // km
+}
+
+func (c circular) period() time.Duration {
+	return time.Duration(2 * math.Pi * math.Sqrt(c.radius*c.radius*c.radius/mu) * float64(time.Second))
+}
+
+func (c circular) StateAt(t time.Time) (observer.State, error) {
+	n := math.Sqrt(mu / (c.radius * c.radius * c.radius))
+	sin, cos := math.Sincos(n * t.Sub(c.epoch).Seconds())
+	return observer.State{
+		Position: observer.Vector{c.radius * cos, c.radius * sin, 0},
+		Velocity: observer.Vector{-c.radius * n * sin, c.radius * n * cos, 0},
+	}, nil
+}
+
+var start = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFind(t *testing.T) {
+	sat := circular{epoch: start, radius: 6378.137 + 500}
+	eclipses, err := Find(sat, start, start.Add(24*time.Hour), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(eclipses) < 14 || len(eclipses) > 16 {
+		t.Fatalf("got %d eclipses, want one an orbit", len(eclipses))
+	}
+	for i, e := range eclipses {
+		if e.StartClipped || e.EndClipped {
+			continue
+		}
+		// a cylindrical shadow gives 37.7% of the orbit at this beta angle
+		if math.Abs(e.OrbitFraction-0.377) > 0.01 {
+			t.Errorf("eclipse %d: %.1f%% of the orbit in shadow", i, e.OrbitFraction*100)
+		}
+		penumbra := e.Duration - e.UmbraDuration
+		if e.UmbraEntry.Before(e.Entry) || e.UmbraExit.After(e.Exit) || penumbra <= 0 || penumbra > 30*time.Second {
+			t.Errorf("eclipse %d: umbra %v-%v in %v-%v", i, e.UmbraEntry, e.UmbraExit, e.Entry, e.Exit)
+		}
+		for _, edge := range []time.Time{e.Entry.Add(-time.Second), e.Exit.Add(time.Second)} {
+			if s, _ := sun.ShadowAt(sat, edge); s != sun.Sunlit {
+				t.Errorf("eclipse %d: %v just outside at %v", i, s, edge)
+			}
+		}
+		// the beta angle of an equatorial orbit is the Sun's declination
+		s := sun.Position(e.Entry)
+		if dec := math.Asin(s[2]/s.Norm()) * 180 / math.Pi; math.Abs(e.Beta-dec) > 1e-9 {
+			t.Errorf("eclipse %d: beta %.2f°, want %.2f°", i, e.Beta, dec)
+		}
+		if i > 0 {
+			if d := e.Entry.Sub(eclipses[i-1].Entry) - sat.period(); d.Abs() > 10*time.Second {
+				t.Errorf("eclipse %d: %v after the previous one, want a period", i, e.Entry.Sub(eclipses[i-1].Entry))
+			}
+		}
+	}
+	if period := Period(observer.State{Position: observer.Vector{sat.radius, 0, 0},
+		Velocity: observer.Vector{0, math.Sqrt(mu / sat.radius), 0}}); (period - sat.period()).Abs() > time.Millisecond {
+		t.Errorf("Period() = %v, want %v", period, sat.period())
+	}
+}
+
+func TestFindClipped(t *testing.T) {
+	sat := circular{epoch: start, radius: 6378.137 + 500}
+	all, err := Find(sat, start, start.Add(4*time.Hour), Options{})
+	if err != nil || len(all) < 2 {
+		t.Fatalf("Find() = %d eclipses, %v", len(all), err)
+	}
+	middle := all[1].Entry.Add(all[1].Duration / 2)
+	clipped, err := Find(sat, middle, middle.Add(time.Hour), Options{})
+	if err != nil || len(clipped) == 0 {
+		t.Fatalf("Find() = %d eclipses, %v", len(clipped), err)
+	}
+	if e := clipped[0]; !e.StartClipped || !e.Entry.Equal(middle) || (e.Exit.Sub(all[1].Exit)).Abs() > time.Second {
+		t.Errorf("clipped eclipse %+v, want from %v to %v", e, middle, all[1].Exit)
+	}
+}
+
+func TestNoEclipseSeason(t *testing.T) {
+	// geostationary satellites are only eclipsed around the equinoxes
+	solstice := time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC)
+	geo := circular{epoch: solstice, radius: 42164}
+	if eclipses, err := Find(geo, solstice, solstice.Add(48*time.Hour), Options{}); err != nil || len(eclipses) != 0 {
+		t.Errorf("Find() = %d eclipses, %v, want none", len(eclipses), err)
+	}
+	equinox := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	geo.epoch = equinox
+	if eclipses, err := Find(geo, equinox, equinox.Add(48*time.Hour), Options{}); err != nil || len(eclipses) != 2 {
+		t.Errorf("Find() = %d eclipses, %v, want one a day", len(eclipses), err)
+	}
+}
+
+func TestMeanPlane(t *testing.T) {
+	tests := []struct {
+		name                    string
+		inclination, meanMotion float64
+		want                    float64 // node rate, degrees per day
+	}{
+		{"ISS", 51.64, 15.5, -5.0},
+		// sun-synchronous: the plane follows the Sun round the year
+		{"sun-synchronous", 98.6, 14.3, 360 / 365.2422},
+		{"polar", 90, 15, 0},
+	}
+	for _, tt := range tests {
+		plane := NewMeanPlane(tle.Elements{Epoch: start, Inclination: tt.inclination, MeanMotion: tt.meanMotion})
+		if math.Abs(plane.NodeRate-tt.want) > 0.05 {
+			t.Errorf("%s: node turns %.3f°/day, want %.3f°/day", tt.name, plane.NodeRate, tt.want)
+		}
+		later := start.Add(10 * 24 * time.Hour)
+		if n := plane.Normal(later); math.Abs(n.Norm()-1) > 1e-12 || math.Abs(n[2]-math.Cos(tt.inclination*deg)) > 1e-12 {
+			t.Errorf("%s: normal %v", tt.name, n)
+		}
+	}
+
+	// a polar orbit rising over the equator at 90° right ascension runs
+	// north along the y axis, so its angular momentum points along x
+	polar := NewMeanPlane(tle.Elements{Epoch: start, Inclination: 90, RightAscension: 90, MeanMotion: 15})
+	if n := polar.Normal(start); n.Sub(observer.Vector{1, 0, 0}).Norm() > 1e-12 {
+		t.Errorf("polar normal %v, want the x axis", n)
+	}
+
+	// the mean plane of the equatorial test orbit is its osculating plane
+	sat := circular{epoch: start, radius: 6878}
+	plane := NewMeanPlane(tle.Elements{Epoch: start, MeanMotion: 86400 / sat.period().Seconds()})
+	eclipses, err := Find(sat, start, start.Add(6*time.Hour), Options{Plane: &plane})
+	if err != nil || len(eclipses) == 0 {
+		t.Fatalf("Find() = %d eclipses, %v", len(eclipses), err)
+	}
+	for i, e := range eclipses {
+		s, _ := sat.StateAt(e.Entry)
+		if math.Abs(e.Beta-Beta(s, e.Entry)) > 1e-9 {
+			t.Errorf("eclipse %d: beta %.4f° from the mean plane, %.4f° osculating", i, e.Beta, Beta(s, e.Entry))
+		}
+	}
+}
+
+func TestBetaSeries(t *testing.T) {
+	equatorial := MeanPlane{Epoch: start}
+	samples, err := BetaSeries(equatorial, start, start.Add(10*24*time.Hour), 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 11 {
+		t.Fatalf("got %d samples", len(samples))
+	}
+	// the Sun climbs towards the equator through March
+	for i := 1; i < len(samples); i++ {
+		if samples[i].Beta <= samples[i-1].Beta {
+			t.Errorf("beta fell from %.2f° to %.2f°", samples[i-1].Beta, samples[i].Beta)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBeta(&buf, "csv", samples[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 || lines[0] != "time,beta_deg" {
+		t.Errorf("WriteBeta() = %s", buf.String())
+	}
+}
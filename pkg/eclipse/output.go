@@ -0,0 +1,92 @@
+package eclipse
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/output"
+)
+
+// eclipseJSON is the JSON and CSV form of an Eclipse.
+type eclipseJSON struct {
+	Entry         string  `json:"entry"`
+	Exit          string  `json:"exit"`
+	Duration      float64 `json:"duration_seconds"`
+	UmbraEntry    string  `json:"umbra_entry,omitempty"`
+	UmbraExit     string  `json:"umbra_exit,omitempty"`
+	UmbraDuration float64 `json:"umbra_duration_seconds"`
+	OrbitPercent  float64 `json:"orbit_percent"`
+	Beta          float64 `json:"beta_deg"`
+	StartClipped  bool    `json:"start_clipped,omitempty"`
+	EndClipped    bool    `json:"end_clipped,omitempty"`
+}
+
+func toJSON(e Eclipse) eclipseJSON {
+	out := eclipseJSON{
+		Entry:         e.Entry.UTC().Format(output.TimeFormat),
+		Exit:          e.Exit.UTC().Format(output.TimeFormat),
+		Duration:      e.Duration.Seconds(),
+		UmbraDuration: e.UmbraDuration.Seconds(),
+		OrbitPercent:  e.OrbitFraction * 100,
+		Beta:          e.Beta,
+		StartClipped:  e.StartClipped,
+		EndClipped:    e.EndClipped,
+	}
+	if !e.UmbraEntry.IsZero() {
+		out.UmbraEntry = e.UmbraEntry.UTC().Format(output.TimeFormat)
+		out.UmbraExit = e.UmbraExit.UTC().Format(output.TimeFormat)
+	}
+	return out
+}
+
+// Write writes eclipses in format: table, json or csv. Tables are in UTC,
+// with times cut to the span marked with an asterisk.
+func Write(w io.Writer, format string, eclipses []Eclipse) error {
+	table := [][]string{{"ENTRY (UTC)", "EXIT (UTC)", "DURATION", "UMBRA", "ORBIT", "BETA"}}
+	records := [][]string{{"entry", "exit", "duration_seconds", "umbra_entry", "umbra_exit", "umbra_duration_seconds",
+		"orbit_percent", "beta_deg", "start_clipped", "end_clipped"}}
+	out := make([]eclipseJSON, 0, len(eclipses))
+	for _, e := range eclipses {
+		j := toJSON(e)
+		entry, exit := j.Entry, j.Exit
+		if e.StartClipped {
+			entry += "*"
+		}
+		if e.EndClipped {
+			exit += "*"
+		}
+		table = append(table, []string{entry, exit,
+			e.Duration.Round(time.Second).String(), e.UmbraDuration.Round(time.Second).String(),
+			fmt.Sprintf("%.1f%%", j.OrbitPercent), fmt.Sprintf("%.1f°", e.Beta),
+		})
+		records = append(records, []string{
+			j.Entry, j.Exit, output.FormatFloat(j.Duration), j.UmbraEntry, j.UmbraExit, output.FormatFloat(j.UmbraDuration),
+			output.FormatFloat(j.OrbitPercent), output.FormatFloat(j.Beta),
+			strconv.FormatBool(j.StartClipped), strconv.FormatBool(j.EndClipped),
+		})
+		out = append(out, j)
+	}
+	return output.Write(w, format, output.Data{Table: table, CSV: records, JSON: out})
+}
+
+// betaJSON is the JSON form of a BetaSample.
+type betaJSON struct {
+	Time string  `json:"time"`
+	Beta float64 `json:"beta_deg"`
+}
+
+// WriteBeta writes a beta angle series in format: table, json or csv.
+func WriteBeta(w io.Writer, format string, samples []BetaSample) error {
+	table := [][]string{{"TIME (UTC)", "BETA"}}
+	records := [][]string{{"time", "beta_deg"}}
+	out := make([]betaJSON, 0, len(samples))
+	for _, s := range samples {
+		t := s.Time.UTC().Format(output.TimeFormat)
+		table = append(table, []string{s.Time.UTC().Format(time.DateTime), fmt.Sprintf("%.2f°", s.Beta)})
+		records = append(records, []string{t, output.FormatFloat(s.Beta)})
+		out = append(out, betaJSON{Time: t, Beta: s.Beta})
+	}
+	return output.Write(w, format, output.Data{Table: table, CSV: records, JSON: out})
+}
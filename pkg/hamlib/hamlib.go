@@ -0,0 +1,104 @@
+// Package hamlib drives antenna rotators and radios through the hamlib
+// network daemons, rotctld and rigctld, over their line-based TCP protocol.
+package hamlib
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default ports of the daemons.
+const (
+	RigPort     = "4532"
+	RotatorPort = "4533"
+)
+
+// Timeout bounds each command's round trip.
+var Timeout = 5 * time.Second
+
+// conn is a connection to a daemon, which answers every set command with
+// a "RPRT <code>" line, 0 meaning success.
+type conn struct {
+	c net.Conn
+	r *bufio.Reader
+}
+
+func dial(ctx context.Context, addr, port string) (*conn, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, port)
+	}
+	var d net.Dialer
+	c, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	return &conn{c: c, r: bufio.NewReader(c)}, nil
+}
+
+// command sends one command line and checks the report.
+func (c *conn) command(line string) error {
+	c.c.SetDeadline(time.Now().Add(Timeout))
+	if _, err := fmt.Fprintf(c.c, "%s\n", line); err != nil {
+		return fmt.Errorf("failed to send %q: %w", line, err)
+	}
+	reply, err := c.r.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("no reply to %q: %w", line, err)
+	}
+	reply = strings.TrimSpace(reply)
+	code, ok := strings.CutPrefix(reply, "RPRT ")
+	if !ok {
+		return fmt.Errorf("unexpected reply to %q: %q", line, reply)
+	}
+	if n, err := strconv.Atoi(code); err != nil || n != 0 {
+		return fmt.Errorf("%q failed with %s", line, reply)
+	}
+	return nil
+}
+
+func (c *conn) Close() error {
+	return c.c.Close()
+}
+
+// Rotator is a connection to rotctld.
+type Rotator struct {
+	*conn
+}
+
+// DialRotator connects to rotctld at addr, host or host:port.
+func DialRotator(ctx context.Context, addr string) (*Rotator, error) {
+	c, err := dial(ctx, addr, RotatorPort)
+	if err != nil {
+		return nil, err
+	}
+	return &Rotator{c}, nil
+}
+
+// SetPosition points the rotator at an azimuth and elevation in degrees.
+func (r *Rotator) SetPosition(azimuth, elevation float64) error {
+	return r.command(fmt.Sprintf("P %.2f %.2f", azimuth, elevation))
+}
+
+// Rig is a connection to rigctld.
+type Rig struct {
+	*conn
+}
+
+// DialRig connects to rigctld at addr, host or host:port.
+func DialRig(ctx context.Context, addr string) (*Rig, error) {
+	c, err := dial(ctx, addr, RigPort)
+	if err != nil {
+		return nil, err
+	}
+	return &Rig{c}, nil
+}
+
+// SetFrequency tunes the rig's current VFO to f Hz.
+func (r *Rig) SetFrequency(f float64) error {
+	return r.command(fmt.Sprintf("F %.0f", f))
+}
@@ -0,0 +1,211 @@
+package hamlib
+
+import (
+	"bufio"
+	"context"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+)
+
+// fakeDaemon is a local stand-in for rotctld or rigctld that records the
+// commands it gets and answers each with reply.
+type fakeDaemon struct {
+	ln    net.Listener
+	reply string
+
+	mu    sync.Mutex
+	lines []string
+}
+
+func newFakeDaemon(t *testing.T, reply string) *fakeDaemon {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &fakeDaemon{ln: ln, reply: reply}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go d.serve(c)
+		}
+	}()
+	return d
+}
+
+func (d *fakeDaemon) serve(c net.Conn) {
+	defer c.Close()
+	scanner := bufio.NewScanner(c)
+	for scanner.Scan() {
+		d.mu.Lock()
+		d.lines = append(d.lines, scanner.Text())
+		d.mu.Unlock()
+		c.Write([]byte(d.reply + "\n"))
+	}
+}
+
+func (d *fakeDaemon) received() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.lines...)
+}
+
+func TestRotatorAndRig(t *testing.T) {
+	ctx := context.Background()
+	rotctld := newFakeDaemon(t, "RPRT 0")
+	rot, err := DialRotator(ctx, rotctld.ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rot.Close()
+	if err := rot.SetPosition(123.456, 7.891); err != nil {
+		t.Fatalf("SetPosition() error = %v", err)
+	}
+
+	rigctld := newFakeDaemon(t, "RPRT 0")
+	rig, err := DialRig(ctx, rigctld.ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rig.Close()
+	if err := rig.SetFrequency(145.8034e6); err != nil {
+		t.Fatalf("SetFrequency() error = %v", err)
+	}
+
+	if got := rotctld.received(); len(got) != 1 || got[0] != "P 123.46 7.89" {
+		t.Errorf("rotctld got %q", got)
+	}
+	if got := rigctld.received(); len(got) != 1 || got[0] != "F 145803400" {
+		t.Errorf("rigctld got %q", got)
+	}
+}
+
+func TestCommandError(t *testing.T) {
+	d := newFakeDaemon(t, "RPRT -1")
+	rot, err := DialRotator(context.Background(), d.ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rot.Close()
+	if err := rot.SetPosition(0, 0); err == nil || !strings.Contains(err.Error(), "RPRT -1") {
+		t.Errorf("SetPosition() error = %v, want the report", err)
+	}
+}
+
+func TestFlip(t *testing.T) {
+	az, el := Flip(350, 30)
+	if math.Abs(az-170) > 1e-9 || math.Abs(el-150) > 1e-9 {
+		t.Errorf("Flip(350, 30) = %v, %v", az, el)
+	}
+	if !CrossesNorth([]float64{300, 340, 10, 60}) {
+		t.Error("a path through north should cross it")
+	}
+	if CrossesNorth([]float64{300, 200, 120, 60}) {
+		t.Error("a path through south should not cross north")
+	}
+}
+
+// circular is a satellite on a circular equatorial orbit.
+type circular struct {
+	epoch  time.Time
+	radius float64 // km
+}
+
+func (c circular) StateAt(t time.Time) (observer.State, error) {
+	n := math.Sqrt(398600.4418 / (c.radius * c.radius * c.radius))
+	sin, cos := math.Sincos(n * t.Sub(c.epoch).Seconds())
+	return observer.State{
+		Position: observer.Vector{c.radius * cos, c.radius * sin, 0},
+		Velocity: observer.Vector{-c.radius * n * sin, c.radius * n * cos, 0},
+	}, nil
+}
+
+func TestControllerFlip(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	sat := circular{epoch: start, radius: 6378.137 + 500}
+
+	// the orbit passes north of a station just south of the equator, so
+	// the rotator flips, and south of one just north of it, so it does not
+	for _, tt := range []struct {
+		latitude float64
+		flipped  bool
+	}{{-1, true}, {1, false}} {
+		d := newFakeDaemon(t, "RPRT 0")
+		rot, err := DialRotator(context.Background(), d.ln.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		site := observer.Observer{Latitude: tt.latitude}
+		c := &Controller{Rotator: rot, MinElevation: 5, Flip: true, Propagator: sat, Observer: site}
+
+		var sent int
+		for at := start; at.Before(start.Add(2 * time.Hour)); at = at.Add(30 * time.Second) {
+			look, err := site.LookAt(sat, at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.Update(at, look); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			if look.Elevation >= c.MinElevation {
+				sent++
+				if c.Flipped() != tt.flipped {
+					t.Fatalf("latitude %v: flipped = %v at %v", tt.latitude, c.Flipped(), at)
+				}
+			}
+		}
+		rot.Close()
+		if sent == 0 {
+			t.Fatalf("latitude %v: no pass above the mask", tt.latitude)
+		}
+		// each command waits for its reply, so all are in
+		if got := d.received(); len(got) != sent {
+			t.Errorf("latitude %v: sent %d positions, want %d", tt.latitude, len(got), sent)
+		}
+	}
+}
+
+func TestControllerPlanMask(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	sat := circular{epoch: start, radius: 6378.137 + 500}
+	site := observer.Observer{Latitude: -1}
+	d := newFakeDaemon(t, "RPRT 0")
+	rot, err := DialRotator(context.Background(), d.ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rot.Close()
+	c := &Controller{Rotator: rot, MinElevation: 20, Flip: true, Propagator: sat, Observer: site}
+
+	for at := start; at.Before(start.Add(2 * time.Hour)); at = at.Add(10 * time.Second) {
+		look, err := site.LookAt(sat, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if look.Elevation < c.MinElevation {
+			continue
+		}
+		if err := c.Update(at, look); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		// the planned pass ends where it sinks below the mask, not the horizon
+		end, err := site.LookAt(sat, c.passEnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(end.Elevation-c.MinElevation) > 0.1 {
+			t.Errorf("pass planned to end at %.2f° elevation, want the %v° mask", end.Elevation, c.MinElevation)
+		}
+		return
+	}
+	t.Fatal("no pass above the mask")
+}
@@ -0,0 +1,113 @@
+package hamlib
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/doppler"
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+	"github.com/Mohammed-Ashour/tlego/pkg/passes"
+)
+
+const (
+	// planSpan bounds how far ahead a pass is planned; longer passes are
+	// planned again when it runs out.
+	planSpan = 2 * time.Hour
+	// planStep is the spacing of the azimuths checked for a flip.
+	planStep = 10 * time.Second
+)
+
+// Flip returns the pointing that reaches the same direction from the other
+// side of the zenith, which a rotator with 0-180° elevation can use.
+func Flip(azimuth, elevation float64) (float64, float64) {
+	return math.Mod(azimuth+180, 360), 180 - elevation
+}
+
+// CrossesNorth reports whether a path of azimuths passes through north,
+// where a 0-360° rotator has its end stop and would swing all the way round.
+func CrossesNorth(azimuths []float64) bool {
+	for i := 1; i < len(azimuths); i++ {
+		if math.Abs(azimuths[i]-azimuths[i-1]) > 180 {
+			return true
+		}
+	}
+	return false
+}
+
+// Controller steers a rotator and tunes a rig as a satellite moves. Either
+// may be nil.
+type Controller struct {
+	Rotator *Rotator
+	Rig     *Rig
+
+	// Downlink is the nominal frequency in Hz the rig is kept tuned to,
+	// corrected for Doppler. Zero leaves the rig alone.
+	Downlink float64
+	// MinElevation masks the rotator: below it in degrees it is not moved.
+	MinElevation float64
+	// Flip lets a rotator with 0-180° elevation follow passes through north
+	// flipped over the zenith, instead of swinging round mid-pass.
+	Flip bool
+
+	// Propagator and Observer plan each pass for Flip.
+	Propagator observer.Propagator
+	Observer   observer.Observer
+
+	passEnd time.Time
+	flipped bool
+}
+
+// Update sends the look at t to the rig and rotator.
+func (c *Controller) Update(t time.Time, look observer.Look) error {
+	if c.Rig != nil && c.Downlink != 0 {
+		if err := c.Rig.SetFrequency(doppler.Downlink(c.Downlink, look.RangeRate)); err != nil {
+			return err
+		}
+	}
+	if c.Rotator == nil || look.Elevation < c.MinElevation {
+		return nil
+	}
+	if c.Flip && !t.Before(c.passEnd) {
+		if err := c.plan(t); err != nil {
+			return err
+		}
+	}
+	az, el := look.Azimuth, look.Elevation
+	if c.flipped {
+		az, el = Flip(az, el)
+	}
+	return c.Rotator.SetPosition(az, el)
+}
+
+// Flipped reports whether the rotator follows the current pass flipped.
+func (c *Controller) Flipped() bool {
+	return c.flipped
+}
+
+// plan decides whether the pass under way at t is followed flipped. Only the
+// part of the pass above MinElevation counts, as the rotator rests below it.
+func (c *Controller) plan(t time.Time) error {
+	opts := passes.Options{MinElevation: c.MinElevation}
+	found, err := passes.Find(c.Propagator, c.Observer, t, t.Add(planSpan), opts)
+	if err != nil {
+		return fmt.Errorf("failed to plan the pass: %w", err)
+	}
+	c.flipped, c.passEnd = false, t.Add(planStep)
+	if len(found) == 0 {
+		return nil
+	}
+	pass := found[0]
+	path, err := observer.Track(c.Propagator, c.Observer, t, pass.LOS, planStep)
+	if err != nil {
+		return fmt.Errorf("failed to plan the pass: %w", err)
+	}
+	var azimuths []float64
+	for _, s := range path {
+		if s.Elevation >= c.MinElevation {
+			azimuths = append(azimuths, s.Azimuth)
+		}
+	}
+	c.flipped, c.passEnd = CrossesNorth(azimuths), pass.LOS
+	return nil
+}
@@ -0,0 +1,145 @@
+// Package observer computes where a satellite appears to an observer on the
+// ground: azimuth, elevation, range and range rate.
+package observer
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// WGS84 ellipsoid and Earth rotation.
+const (
+	earthRadius     = 6378.137 // equatorial radius, km
+	earthFlattening = 1 / 298.257223563
+	earthRotation   = 7.292115146706979e-5 // rad/s
+)
+
+const deg = math.Pi / 180
+
+// Vector is a Cartesian vector in km or km/s.
+type Vector [3]float64
+
+func (v Vector) Sub(w Vector) Vector  { return Vector{v[0] - w[0], v[1] - w[1], v[2] - w[2]} }
+func (v Vector) Dot(w Vector) float64 { return v[0]*w[0] + v[1]*w[1] + v[2]*w[2] }
+func (v Vector) Norm() float64        { return math.Sqrt(v.Dot(v)) }
+func (v Vector) Cross(w Vector) Vector {
+	return Vector{v[1]*w[2] - v[2]*w[1], v[2]*w[0] - v[0]*w[2], v[0]*w[1] - v[1]*w[0]}
+}
+
+// State is the position and velocity of a satellite in the TEME frame used
+// by SGP4, in km and km/s.
+type State struct {
+	Position Vector
+	Velocity Vector
+}
+
+// Propagator gives the state of a satellite at any time.
+type Propagator interface {
+	StateAt(t time.Time) (State, error)
+}
+
+// Observer is a place on the ground.
+type Observer struct {
+	Latitude  float64 // geodetic, degrees north
+	Longitude float64 // degrees east
+	Altitude  float64 // meters above the WGS84 ellipsoid
+}
+
+// Validate checks the coordinates are on the globe.
+func (o Observer) Validate() error {
+	switch {
+	case math.IsNaN(o.Latitude) || o.Latitude < -90 || o.Latitude > 90:
+		return fmt.Errorf("latitude %v out of range [-90, 90]", o.Latitude)
+	case math.IsNaN(o.Longitude) || o.Longitude < -180 || o.Longitude > 360:
+		return fmt.Errorf("longitude %v out of range [-180, 360]", o.Longitude)
+	case math.IsNaN(o.Altitude):
+		return fmt.Errorf("invalid altitude %v", o.Altitude)
+	}
+	return nil
+}
+
+// ECEF returns the observer's Earth-fixed position in km.
+func (o Observer) ECEF() Vector {
+	lat, lon, h := o.Latitude*deg, o.Longitude*deg, o.Altitude/1000
+	e2 := earthFlattening * (2 - earthFlattening)
+	n := earthRadius / math.Sqrt(1-e2*math.Sin(lat)*math.Sin(lat))
+	return Vector{
+		(n + h) * math.Cos(lat) * math.Cos(lon),
+		(n + h) * math.Cos(lat) * math.Sin(lon),
+		(n*(1-e2) + h) * math.Sin(lat),
+	}
+}
+
+// Look is the direction and distance of a satellite seen by an observer.
+type Look struct {
+	Azimuth   float64 `json:"azimuth_deg"`   // degrees clockwise from north
+	Elevation float64 `json:"elevation_deg"` // degrees above the horizon
+	Range     float64 `json:"range_km"`
+	RangeRate float64 `json:"range_rate_km_s"` // positive while receding
+}
+
+// Look returns where a satellite in state s at time t appears to o.
+func (o Observer) Look(s State, t time.Time) Look {
+	pos, vel := TEMEToECEF(s, t)
+	rho := pos.Sub(o.ECEF())
+	rng := rho.Norm()
+
+	lat, lon := o.Latitude*deg, o.Longitude*deg
+	south := math.Sin(lat)*math.Cos(lon)*rho[0] + math.Sin(lat)*math.Sin(lon)*rho[1] - math.Cos(lat)*rho[2]
+	east := -math.Sin(lon)*rho[0] + math.Cos(lon)*rho[1]
+	zenith := math.Cos(lat)*math.Cos(lon)*rho[0] + math.Cos(lat)*math.Sin(lon)*rho[1] + math.Sin(lat)*rho[2]
+
+	az := math.Atan2(east, -south) / deg
+	if az < 0 {
+		az += 360
+	}
+	return Look{
+		Azimuth:   az,
+		Elevation: math.Asin(zenith/rng) / deg,
+		Range:     rng,
+		RangeRate: rho.Dot(vel) / rng,
+	}
+}
+
+// LookAt propagates p to t and returns where the satellite appears to o.
+func (o Observer) LookAt(p Propagator, t time.Time) (Look, error) {
+	s, err := p.StateAt(t)
+	if err != nil {
+		return Look{}, err
+	}
+	return o.Look(s, t), nil
+}
+
+// TEMEToECEF rotates a TEME state into the Earth-fixed frame at t, ignoring
+// polar motion. The velocity is relative to the rotating Earth.
+func TEMEToECEF(s State, t time.Time) (pos, vel Vector) {
+	g := GMST(t)
+	sin, cos := math.Sincos(g)
+	rotate := func(v Vector) Vector {
+		return Vector{cos*v[0] + sin*v[1], -sin*v[0] + cos*v[1], v[2]}
+	}
+	pos = rotate(s.Position)
+	vel = rotate(s.Velocity)
+	vel[0] += earthRotation * pos[1]
+	vel[1] -= earthRotation * pos[0]
+	return pos, vel
+}
+
+// GMST returns the Greenwich mean sidereal time at t in radians (IAU 1982),
+// keeping the fractions of a second that satellite.GSTimeFromDate drops.
+func GMST(t time.Time) float64 {
+	tut1 := (JulianDate(t) - 2451545.0) / 36525
+	seconds := -6.2e-6*tut1*tut1*tut1 + 0.093104*tut1*tut1 +
+		(876600*3600+8640184.812866)*tut1 + 67310.54841
+	g := math.Mod(seconds*deg/240, 2*math.Pi)
+	if g < 0 {
+		g += 2 * math.Pi
+	}
+	return g
+}
+
+// JulianDate returns the Julian date of t.
+func JulianDate(t time.Time) float64 {
+	return float64(t.UnixNano())/float64(24*time.Hour) + 2440587.5
+}
@@ -0,0 +1,107 @@
+package observer
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// overhead returns the TEME state of a satellite alt km straight above o at
+// t, at rest in the inertial frame.
+func overhead(o Observer, t time.Time, alt float64) State {
+	lat, lon := o.Latitude*deg, o.Longitude*deg
+	normal := Vector{math.Cos(lat) * math.Cos(lon), math.Cos(lat) * math.Sin(lon), math.Sin(lat)}
+	base := o.ECEF()
+	ecef := Vector{base[0] + alt*normal[0], base[1] + alt*normal[1], base[2] + alt*normal[2]}
+	sin, cos := math.Sincos(GMST(t))
+	return State{Position: Vector{cos*ecef[0] - sin*ecef[1], sin*ecef[0] + cos*ecef[1], ecef[2]}}
+}
+
+func TestGMST(t *testing.T) {
+	j2000 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	if got, want := GMST(j2000)/deg, 280.46061837; math.Abs(got-want) > 1e-6 {
+		t.Errorf("GMST(J2000) = %.8f°, want %.8f°", got, want)
+	}
+	// a sidereal day is about 3m56s shorter than a solar day
+	if got := (GMST(j2000.Add(24*time.Hour)) - GMST(j2000)) / deg; math.Abs(got-0.9856) > 1e-3 {
+		t.Errorf("GMST advanced %.4f° in a day, want 0.9856°", got)
+	}
+}
+
+func TestLookOverhead(t *testing.T) {
+	now := time.Date(2024, 3, 1, 6, 30, 15, 0, time.UTC)
+	for _, o := range []Observer{{0, 0, 0}, {52.5, 13.4, 35}, {-33.9, 151.2, 0}} {
+		look := o.Look(overhead(o, now, 500), now)
+		if look.Elevation < 89.9 {
+			t.Errorf("%+v: elevation %.4f°, want 90°", o, look.Elevation)
+		}
+		if math.Abs(look.Range-500) > 0.5 {
+			t.Errorf("%+v: range %.3f km, want 500", o, look.Range)
+		}
+	}
+}
+
+func TestLookRangeRate(t *testing.T) {
+	o := Observer{Latitude: 0, Longitude: 0}
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	s := overhead(o, now, 1000)
+	// move the satellite off to the north and send it further north
+	s.Position[2] += 500
+	s.Velocity[2] = 7
+	look := o.Look(s, now)
+	if look.Azimuth > 1 && look.Azimuth < 359 {
+		t.Errorf("azimuth %.2f°, want north", look.Azimuth)
+	}
+	if look.RangeRate <= 0 {
+		t.Errorf("range rate %.3f km/s, want receding", look.RangeRate)
+	}
+}
+
+func TestHermite(t *testing.T) {
+	// uniformly accelerated motion is reproduced exactly by a cubic
+	at := func(s float64) State {
+		return State{Position: Vector{1 + 2*s + 0.5*s*s, 0, 0}, Velocity: Vector{2 + s, 0, 0}}
+	}
+	got := hermite(at(0), at(1), 0.25)
+	want := at(0.25)
+	if math.Abs(got.Position[0]-want.Position[0]) > 1e-12 || math.Abs(got.Velocity[0]-want.Velocity[0]) > 1e-12 {
+		t.Errorf("hermite = %+v, want %+v", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, o := range []Observer{{Latitude: 91}, {Longitude: -181}, {Altitude: math.NaN()}} {
+		if o.Validate() == nil {
+			t.Errorf("%+v: expected a validation error", o)
+		}
+	}
+	if err := (Observer{51.5, -0.1, 20}).Validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// fixed is a satellite at rest in the inertial frame.
+type fixed State
+
+func (f fixed) StateAt(time.Time) (State, error) { return State(f), nil }
+
+func TestTrack(t *testing.T) {
+	o := Observer{Latitude: 10, Longitude: 20}
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	p := fixed(overhead(o, start, 800))
+
+	samples, err := Track(p, o, start, start.Add(95*time.Second), 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 5 || !samples[4].Time.Equal(start.Add(95*time.Second)) {
+		t.Fatalf("got %d samples ending %v", len(samples), samples[len(samples)-1].Time)
+	}
+	// the Earth turns away beneath the satellite
+	if samples[0].Elevation < 89.9 || samples[4].Elevation >= samples[0].Elevation || samples[4].RangeRate <= 0 {
+		t.Errorf("samples = %+v", samples)
+	}
+	if _, err := Track(p, o, start, start.Add(-time.Second), time.Second); err == nil {
+		t.Error("expected an error for a reversed span")
+	}
+}
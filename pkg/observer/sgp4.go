@@ -0,0 +1,84 @@
+package observer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/satellite"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// ErrPropagation is returned when SGP4 cannot place the satellite, usually
+// because the element set has decayed too far from its epoch.
+var ErrPropagation = errors.New("propagation failed")
+
+// SGP4 propagates an element set with go-satellite-v2.
+type SGP4 struct {
+	sat satellite.Satellite
+}
+
+// NewSGP4 prepares t for propagation. Element sets read from OMMs without
+// TLE lines are encoded first.
+func NewSGP4(t tle.TLE) (*SGP4, error) {
+	line1, line2 := t.Line1.LineString, t.Line2.LineString
+	if line1 == "" || line2 == "" {
+		var err error
+		if line1, line2, err = t.Elements.Encode(); err != nil {
+			return nil, fmt.Errorf("%s: cannot propagate: %w", t.Name, err)
+		}
+	}
+	return &SGP4{sat: satellite.TLEToSat(line1, line2, satellite.GravityWGS84)}, nil
+}
+
+// StateAt returns the TEME state at t. satellite.Propagate only takes whole
+// seconds, so states in between are interpolated from the two surrounding
+// seconds with a cubic Hermite spline, which is exact to well under a meter.
+func (p *SGP4) StateAt(t time.Time) (State, error) {
+	t = t.UTC()
+	t0 := t.Truncate(time.Second)
+	s0, err := p.stateAtSecond(t0)
+	if err != nil {
+		return State{}, err
+	}
+	u := t.Sub(t0).Seconds()
+	if u == 0 {
+		return s0, nil
+	}
+	s1, err := p.stateAtSecond(t0.Add(time.Second))
+	if err != nil {
+		return State{}, err
+	}
+	return hermite(s0, s1, u), nil
+}
+
+func (p *SGP4) stateAtSecond(t time.Time) (State, error) {
+	pos, vel := satellite.Propagate(p.sat, t.Year(), int(t.Month()), t.Day(), t.Hour(), t.Minute(), t.Second())
+	s := State{Position: Vector{pos.X, pos.Y, pos.Z}, Velocity: Vector{vel.X, vel.Y, vel.Z}}
+	for _, v := range append(s.Position[:], s.Velocity[:]...) {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return State{}, fmt.Errorf("%s: %w", t.Format(time.RFC3339), ErrPropagation)
+		}
+	}
+	if s.Position.Norm() == 0 {
+		return State{}, fmt.Errorf("%s: %w", t.Format(time.RFC3339), ErrPropagation)
+	}
+	return s, nil
+}
+
+// hermite interpolates between the states s0 and s1 one second apart at the
+// fraction u of that second.
+func hermite(s0, s1 State, u float64) State {
+	u2, u3 := u*u, u*u*u
+	h00, h10, h01, h11 := 2*u3-3*u2+1, u3-2*u2+u, -2*u3+3*u2, u3-u2
+	d00, d10, d01, d11 := 6*u2-6*u, 3*u2-4*u+1, -6*u2+6*u, 3*u2-2*u
+
+	var s State
+	for i := range s.Position {
+		p0, v0, p1, v1 := s0.Position[i], s0.Velocity[i], s1.Position[i], s1.Velocity[i]
+		s.Position[i] = h00*p0 + h10*v0 + h01*p1 + h11*v1
+		s.Velocity[i] = d00*p0 + d10*v0 + d01*p1 + d11*v1
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+package observer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/output"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// LookAngles returns where the satellite of an element set appears to o at t.
+func LookAngles(t tle.TLE, o Observer, at time.Time) (Look, error) {
+	p, err := NewSGP4(t)
+	if err != nil {
+		return Look{}, err
+	}
+	return o.LookAt(p, at)
+}
+
+// Sample is a look at one moment.
+type Sample struct {
+	Time time.Time
+	Look
+}
+
+// Track samples the look angles from start to end every step, both ends
+// included.
+func Track(p Propagator, o Observer, start, end time.Time, step time.Duration) ([]Sample, error) {
+	if step <= 0 {
+		return nil, errors.New("the step must be positive")
+	}
+	if end.Before(start) {
+		return nil, errors.New("the time span must end after it starts")
+	}
+	var samples []Sample
+	for t := start; ; t = t.Add(step) {
+		if t.After(end) {
+			t = end
+		}
+		look, err := o.LookAt(p, t)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, Sample{Time: t, Look: look})
+		if !t.Before(end) {
+			return samples, nil
+		}
+	}
+}
+
+// sampleJSON is the JSON form of a Sample.
+type sampleJSON struct {
+	Time string `json:"time"`
+	Look
+}
+
+// Write writes samples in format: table, json or csv. Tables are in UTC.
+func Write(w io.Writer, format string, samples []Sample) error {
+	table := [][]string{{"TIME (UTC)", "AZIMUTH", "ELEVATION", "RANGE (km)", "RANGE RATE (km/s)"}}
+	records := [][]string{{"time", "azimuth_deg", "elevation_deg", "range_km", "range_rate_km_s"}}
+	out := make([]sampleJSON, 0, len(samples))
+	for _, s := range samples {
+		t := s.Time.UTC().Format(output.TimeFormat)
+		table = append(table, []string{t,
+			fmt.Sprintf("%.2f°", s.Azimuth), fmt.Sprintf("%.2f°", s.Elevation),
+			fmt.Sprintf("%.1f", s.Range), fmt.Sprintf("%.3f", s.RangeRate),
+		})
+		records = append(records, []string{t,
+			output.FormatFloat(s.Azimuth), output.FormatFloat(s.Elevation),
+			output.FormatFloat(s.Range), output.FormatFloat(s.RangeRate),
+		})
+		out = append(out, sampleJSON{Time: t, Look: s.Look})
+	}
+	return output.Write(w, format, output.Data{Table: table, CSV: records, JSON: out})
+}
@@ -0,0 +1,64 @@
+// Package output writes command results in the formats --format offers: an
+// aligned text table, JSON or CSV.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// TimeFormat keeps the milliseconds of times found to sub-second precision.
+const TimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// Data is a result set in each format. The table is formatted for reading
+// and the CSV keeps full precision, so each has its own rows, header first.
+// JSON is the value to encode, usually a slice of structs.
+type Data struct {
+	Table [][]string
+	CSV   [][]string
+	JSON  any
+}
+
+// Write writes d in format: table, json or csv.
+func Write(w io.Writer, format string, d Data) error {
+	switch strings.ToLower(format) {
+	case "", "table":
+		return WriteTable(w, d.Table)
+	case "json":
+		return WriteJSON(w, d.JSON)
+	case "csv":
+		return WriteCSV(w, d.CSV)
+	}
+	return fmt.Errorf("unknown output format %q, expected table, json or csv", format)
+}
+
+// WriteTable writes rows as an aligned text table.
+func WriteTable(w io.Writer, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes v as indented JSON.
+func WriteJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// WriteCSV writes rows as CSV.
+func WriteCSV(w io.Writer, rows [][]string) error {
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// FormatFloat writes v with as many digits as it takes, for CSV.
+func FormatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	d := Data{
+		Table: [][]string{{"NAME", "VALUE"}, {"pi", "3.14"}},
+		CSV:   [][]string{{"name", "value"}, {"pi", FormatFloat(3.14159)}},
+		JSON:  []map[string]float64{{"pi": 3.14159}},
+	}
+	tests := []struct {
+		format, want string
+	}{
+		{"table", "NAME  VALUE\npi    3.14\n"},
+		{"CSV", "name,value\npi,3.14159\n"},
+		{"json", "[\n  {\n    \"pi\": 3.14159\n  }\n]\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Write(&buf, tt.format, d); err != nil || buf.String() != tt.want {
+			t.Errorf("Write(%s) = %q, %v; want %q", tt.format, buf.String(), err, tt.want)
+		}
+	}
+	if err := Write(&bytes.Buffer{}, "yaml", d); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
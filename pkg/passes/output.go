@@ -0,0 +1,110 @@
+package passes
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/output"
+)
+
+// passJSON is the JSON and CSV form of a Pass.
+type passJSON struct {
+	AOS          string   `json:"aos"`
+	TCA          string   `json:"tca"`
+	LOS          string   `json:"los"`
+	AOSAzimuth   float64  `json:"aos_azimuth_deg"`
+	TCAAzimuth   float64  `json:"tca_azimuth_deg"`
+	LOSAzimuth   float64  `json:"los_azimuth_deg"`
+	MaxElevation float64  `json:"max_elevation_deg"`
+	Duration     float64  `json:"duration_seconds"`
+	StartClipped bool     `json:"start_clipped,omitempty"`
+	EndClipped   bool     `json:"end_clipped,omitempty"`
+	Visible      *bool    `json:"visible,omitempty"`
+	VisibleStart string   `json:"visible_start,omitempty"`
+	VisibleEnd   string   `json:"visible_end,omitempty"`
+	Magnitude    *float64 `json:"magnitude,omitempty"`
+}
+
+func toJSON(p Pass) passJSON {
+	out := passJSON{
+		AOS:          p.AOS.UTC().Format(output.TimeFormat),
+		TCA:          p.TCA.UTC().Format(output.TimeFormat),
+		LOS:          p.LOS.UTC().Format(output.TimeFormat),
+		AOSAzimuth:   p.AOSAzimuth,
+		TCAAzimuth:   p.TCAAzimuth,
+		LOSAzimuth:   p.LOSAzimuth,
+		MaxElevation: p.MaxElevation,
+		Duration:     p.Duration.Seconds(),
+		StartClipped: p.StartClipped,
+		EndClipped:   p.EndClipped,
+	}
+	if p.VisibilityChecked {
+		out.Visible = &p.Visible
+	}
+	if p.Visible {
+		out.VisibleStart = p.VisibleStart.UTC().Format(output.TimeFormat)
+		out.VisibleEnd = p.VisibleEnd.UTC().Format(output.TimeFormat)
+	}
+	if !math.IsNaN(p.Magnitude) {
+		out.Magnitude = &p.Magnitude
+	}
+	return out
+}
+
+// Write writes passes in format: table, json or csv. Tables are in UTC,
+// with times cut to the search window marked with an asterisk. VISIBLE is
+// when the satellite can be seen by eye, if checked, and MAG its brightest
+// magnitude then if known.
+func Write(w io.Writer, format string, passes []Pass) error {
+	table := [][]string{{"AOS (UTC)", "AOS AZ", "TCA (UTC)", "MAX EL", "TCA AZ", "LOS (UTC)", "LOS AZ", "DURATION", "VISIBLE", "MAG"}}
+	records := [][]string{{"aos", "tca", "los", "aos_azimuth_deg", "tca_azimuth_deg", "los_azimuth_deg",
+		"max_elevation_deg", "duration_seconds", "start_clipped", "end_clipped",
+		"visible", "visible_start", "visible_end", "magnitude"}}
+	out := make([]passJSON, 0, len(passes))
+	for _, p := range passes {
+		j := toJSON(p)
+
+		aos, los := p.AOS.UTC().Format(time.DateTime), p.LOS.UTC().Format(time.DateTime)
+		if p.StartClipped {
+			aos += "*"
+		}
+		if p.EndClipped {
+			los += "*"
+		}
+		visible, magnitude := "-", "-"
+		if p.VisibilityChecked {
+			visible = "no"
+		}
+		if p.Visible {
+			visible = p.VisibleStart.UTC().Format(time.TimeOnly) + "-" + p.VisibleEnd.UTC().Format(time.TimeOnly)
+		}
+		if j.Magnitude != nil {
+			magnitude = fmt.Sprintf("%.1f", *j.Magnitude)
+		}
+		table = append(table, []string{
+			aos, fmt.Sprintf("%.1f°", p.AOSAzimuth), p.TCA.UTC().Format(time.DateTime),
+			fmt.Sprintf("%.1f°", p.MaxElevation), fmt.Sprintf("%.1f°", p.TCAAzimuth),
+			los, fmt.Sprintf("%.1f°", p.LOSAzimuth), p.Duration.Round(time.Second).String(), visible, magnitude,
+		})
+
+		visible, magnitude = "", ""
+		if j.Visible != nil {
+			visible = strconv.FormatBool(*j.Visible)
+		}
+		if j.Magnitude != nil {
+			magnitude = output.FormatFloat(*j.Magnitude)
+		}
+		records = append(records, []string{
+			j.AOS, j.TCA, j.LOS,
+			output.FormatFloat(j.AOSAzimuth), output.FormatFloat(j.TCAAzimuth), output.FormatFloat(j.LOSAzimuth),
+			output.FormatFloat(j.MaxElevation), output.FormatFloat(j.Duration),
+			strconv.FormatBool(j.StartClipped), strconv.FormatBool(j.EndClipped),
+			visible, j.VisibleStart, j.VisibleEnd, magnitude,
+		})
+		out = append(out, j)
+	}
+	return output.Write(w, format, output.Data{Table: table, CSV: records, JSON: out})
+}
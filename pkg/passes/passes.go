@@ -0,0 +1,315 @@
+// Package passes predicts when satellites rise above and set below an
+// observer's horizon.
+package passes
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+)
+
+// Defaults for Options.
+const (
+	DefaultStep      = 30 * time.Second
+	DefaultPrecision = 10 * time.Millisecond
+	DefaultTwilight  = -6 // civil twilight
+)
+
+// maxElevationRate bounds in degrees per second how fast a satellite's
+// elevation changes, about that of a low orbit passing overhead. A sampled
+// local maximum further below MinElevation than a step at this rate cannot
+// hide a pass between its neighbours.
+const maxElevationRate = 1
+
+// Pass is one visit of a satellite above the observer's horizon. Passes
+// already under way at the start of the search window, or not over by its
+// end, are cut to the window and flagged.
+type Pass struct {
+	AOS          time.Time // acquisition of signal: rise above the horizon
+	TCA          time.Time // time of closest approach: highest elevation
+	LOS          time.Time // loss of signal: set below the horizon
+	AOSAzimuth   float64   // degrees
+	TCAAzimuth   float64
+	LOSAzimuth   float64
+	MaxElevation float64 // degrees
+	Duration     time.Duration
+	StartClipped bool // up before the window started
+	EndClipped   bool // still up when the window ended
+
+	// Visible is whether the satellite can be seen by eye during the pass:
+	// sunlit, against a dark enough sky. VisibleStart and VisibleEnd bound
+	// when, and Magnitude is its brightest estimated visual magnitude then,
+	// NaN if not visible or its standard magnitude is unknown. They are only
+	// worked out, and VisibilityChecked set, when the Options ask for
+	// visible passes, a twilight or a magnitude.
+	Visible           bool
+	VisibleStart      time.Time
+	VisibleEnd        time.Time
+	Magnitude         float64
+	VisibilityChecked bool
+}
+
+// Options tune the search.
+type Options struct {
+	// MinElevation is the horizon in degrees; passes must rise above it.
+	MinElevation float64
+	// Step is how often the elevation is sampled to find passes. Passes that
+	// stay above the horizon for less than a step may be missed unless they
+	// peak near a sample.
+	Step time.Duration
+	// Precision is how closely AOS, TCA and LOS are located.
+	Precision time.Duration
+
+	// Twilight is the solar elevation in degrees the observer's sky must be
+	// darker than to see a satellite; DefaultTwilight if nil.
+	Twilight *float64
+	// VisibleOnly keeps only the passes that are Visible.
+	VisibleOnly bool
+	// StandardMagnitude is the satellite's visual magnitude at 1000 km and
+	// 90° phase, to estimate its brightness; nil if unknown.
+	StandardMagnitude *float64
+}
+
+// Find returns the passes of the satellite propagated by p over o between
+// start and end, in time order.
+func Find(p observer.Propagator, o observer.Observer, start, end time.Time, opts Options) ([]Pass, error) {
+	if !end.After(start) {
+		return nil, errors.New("the search window must end after it starts")
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Step <= 0 {
+		opts.Step = DefaultStep
+	}
+	if opts.Precision <= 0 {
+		opts.Precision = DefaultPrecision
+	}
+	f := finder{p: p, o: o, opts: opts}
+
+	// sample the elevation over the window, ending exactly at its end
+	var times []time.Time
+	var elevations []float64
+	for t := start; ; t = t.Add(opts.Step) {
+		if t.After(end) {
+			t = end
+		}
+		el, err := f.elevation(t)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+		elevations = append(elevations, el)
+		if !t.Before(end) {
+			break
+		}
+	}
+	up := func(i int) bool { return elevations[i] >= opts.MinElevation }
+
+	var passes []Pass
+	var aos time.Time
+	inPass, clipped := up(0), up(0)
+	if inPass {
+		aos = start
+	}
+	for i := 0; i+1 < len(times); i++ {
+		switch {
+		case !up(i) && up(i+1):
+			t, err := f.crossing(times[i], times[i+1], true)
+			if err != nil {
+				return nil, err
+			}
+			aos, inPass, clipped = t, true, false
+		case up(i) && !up(i+1):
+			t, err := f.crossing(times[i], times[i+1], false)
+			if err != nil {
+				return nil, err
+			}
+			pass, err := f.pass(aos, t, clipped, false)
+			if err != nil {
+				return nil, err
+			}
+			passes = append(passes, pass)
+			inPass = false
+		case i > 0 && !up(i-1) && !up(i) && !up(i+1) &&
+			elevations[i] > elevations[i-1] && elevations[i] >= elevations[i+1] &&
+			elevations[i] >= opts.MinElevation-maxElevationRate*opts.Step.Seconds():
+			// a grazing pass can rise and set between two samples; only the
+			// first sample of a plateau counts, so it is not found twice
+			peak, el, err := f.peak(times[i-1], times[i+1])
+			if err != nil {
+				return nil, err
+			}
+			if el < opts.MinElevation {
+				continue
+			}
+			rise, err := f.crossing(times[i-1], peak, true)
+			if err != nil {
+				return nil, err
+			}
+			set, err := f.crossing(peak, times[i+1], false)
+			if err != nil {
+				return nil, err
+			}
+			pass, err := f.pass(rise, set, false, false)
+			if err != nil {
+				return nil, err
+			}
+			passes = append(passes, pass)
+		}
+	}
+	if inPass {
+		pass, err := f.pass(aos, end, clipped, true)
+		if err != nil {
+			return nil, err
+		}
+		passes = append(passes, pass)
+	}
+	if opts.VisibleOnly {
+		passes = slices.DeleteFunc(passes, func(p Pass) bool { return !p.Visible })
+	}
+	return passes, nil
+}
+
+type finder struct {
+	p    observer.Propagator
+	o    observer.Observer
+	opts Options
+}
+
+func (f finder) look(t time.Time) (observer.Look, error) {
+	return f.o.LookAt(f.p, t)
+}
+
+func (f finder) elevation(t time.Time) (float64, error) {
+	look, err := f.look(t)
+	return look.Elevation, err
+}
+
+// crossing bisects [a, b] for the moment the elevation crosses the horizon,
+// upwards if rising.
+func (f finder) crossing(a, b time.Time, rising bool) (time.Time, error) {
+	for b.Sub(a) > f.opts.Precision {
+		mid := a.Add(b.Sub(a) / 2)
+		el, err := f.elevation(mid)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if (el >= f.opts.MinElevation) == rising {
+			b = mid
+		} else {
+			a = mid
+		}
+	}
+	if rising {
+		return b, nil
+	}
+	return a, nil
+}
+
+// invPhi is the golden section ratio used to narrow the peak search.
+var invPhi = (math.Sqrt(5) - 1) / 2
+
+// peak locates the highest elevation in [a, b] by golden-section search,
+// assuming a single maximum.
+func (f finder) peak(a, b time.Time) (time.Time, float64, error) {
+	span := func(x float64) time.Time { return a.Add(time.Duration(x * float64(b.Sub(a)))) }
+	lo, hi := 0.0, 1.0
+	x1, x2 := hi-invPhi*(hi-lo), lo+invPhi*(hi-lo)
+	e1, err := f.elevation(span(x1))
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	e2, err := f.elevation(span(x2))
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	for time.Duration((hi-lo)*float64(b.Sub(a))) > f.opts.Precision {
+		if e1 < e2 {
+			lo, x1, e1 = x1, x2, e2
+			x2 = lo + invPhi*(hi-lo)
+			if e2, err = f.elevation(span(x2)); err != nil {
+				return time.Time{}, 0, err
+			}
+		} else {
+			hi, x2, e2 = x2, x1, e1
+			x1 = hi - invPhi*(hi-lo)
+			if e1, err = f.elevation(span(x1)); err != nil {
+				return time.Time{}, 0, err
+			}
+		}
+	}
+	t := span((lo + hi) / 2)
+	el, err := f.elevation(t)
+	return t, el, err
+}
+
+// pass describes the pass between aos and los. The highest sample is
+// refined to the culmination within a step either side of it.
+func (f finder) pass(aos, los time.Time, startClipped, endClipped bool) (Pass, error) {
+	best, bestEl := aos, math.Inf(-1)
+	for t := aos; ; t = t.Add(f.opts.Step) {
+		if t.After(los) {
+			t = los
+		}
+		el, err := f.elevation(t)
+		if err != nil {
+			return Pass{}, err
+		}
+		if el > bestEl {
+			best, bestEl = t, el
+		}
+		if !t.Before(los) {
+			break
+		}
+	}
+	a, b := best.Add(-f.opts.Step), best.Add(f.opts.Step)
+	if a.Before(aos) {
+		a = aos
+	}
+	if b.After(los) {
+		b = los
+	}
+	tca, maxEl, err := f.peak(a, b)
+	if err != nil {
+		return Pass{}, err
+	}
+	if maxEl < bestEl {
+		tca = best
+	}
+
+	aosLook, err := f.look(aos)
+	if err != nil {
+		return Pass{}, err
+	}
+	tcaLook, err := f.look(tca)
+	if err != nil {
+		return Pass{}, err
+	}
+	losLook, err := f.look(los)
+	if err != nil {
+		return Pass{}, err
+	}
+	pass := Pass{
+		AOS:          aos,
+		TCA:          tca,
+		LOS:          los,
+		AOSAzimuth:   aosLook.Azimuth,
+		TCAAzimuth:   tcaLook.Azimuth,
+		LOSAzimuth:   losLook.Azimuth,
+		MaxElevation: tcaLook.Elevation,
+		Duration:     los.Sub(aos),
+		StartClipped: startClipped,
+		EndClipped:   endClipped,
+		Magnitude:    math.NaN(),
+	}
+	if f.opts.VisibleOnly || f.opts.Twilight != nil || f.opts.StandardMagnitude != nil {
+		if err := f.visibility(&pass); err != nil {
+			return Pass{}, err
+		}
+	}
+	return pass, nil
+}
@@ -0,0 +1,239 @@
+package passes
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+	"github.com/Mohammed-Ashour/tlego/pkg/sun"
+)
+
+// circular is a satellite on a circular equatorial orbit, which passes
+// straight over an observer on the equator.
+type circular struct {
+	epoch  time.Time
+	radius float64 // km
+}
+
+func (c circular) StateAt(t time.Time) (observer.State, error) {
+	n := math.Sqrt(398600.4418 / (c.radius * c.radius * c.radius))
+	theta := n * t.Sub(c.epoch).Seconds()
+	sin, cos := math.Sincos(theta)
+	return observer.State{
+		Position: observer.Vector{c.radius * cos, c.radius * sin, 0},
+		Velocity: observer.Vector{-c.radius * n * sin, c.radius * n * cos, 0},
+	}, nil
+}
+
+var (
+	start = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	sat   = circular{epoch: start, radius: 6378.137 + 500}
+	site  = observer.Observer{Latitude: 0, Longitude: 0}
+)
+
+func TestFind(t *testing.T) {
+	passes, err := Find(sat, site, start, start.Add(24*time.Hour), Options{})
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	// the orbit laps the rotating Earth about 14.2 times a day
+	if len(passes) < 13 || len(passes) > 15 {
+		t.Fatalf("got %d passes, want about 14", len(passes))
+	}
+
+	for i, p := range passes {
+		if !p.AOS.Before(p.TCA) || !p.TCA.Before(p.LOS) || p.Duration != p.LOS.Sub(p.AOS) {
+			t.Errorf("pass %d out of order: %+v", i, p)
+		}
+		if p.MaxElevation < 89.5 {
+			t.Errorf("pass %d peaks at %.3f°, want overhead", i, p.MaxElevation)
+		}
+		if p.StartClipped || p.EndClipped {
+			continue
+		}
+		for _, edge := range []time.Time{p.AOS, p.LOS} {
+			look, _ := site.LookAt(sat, edge)
+			if math.Abs(look.Elevation) > 0.01 {
+				t.Errorf("pass %d: elevation %.4f° at %v, want 0", i, look.Elevation, edge)
+			}
+		}
+		// eastbound: rises in the west, sets in the east
+		if math.Abs(p.AOSAzimuth-270) > 1 || math.Abs(p.LOSAzimuth-90) > 1 {
+			t.Errorf("pass %d azimuths %.1f° -> %.1f°", i, p.AOSAzimuth, p.LOSAzimuth)
+		}
+		if p.Duration < 8*time.Minute || p.Duration > 13*time.Minute {
+			t.Errorf("pass %d lasts %v", i, p.Duration)
+		}
+	}
+}
+
+func TestFindClipped(t *testing.T) {
+	all, err := Find(sat, site, start, start.Add(6*time.Hour), Options{})
+	if err != nil || len(all) < 2 {
+		t.Fatalf("Find() = %d passes, %v", len(all), err)
+	}
+	first := all[0]
+	passes, err := Find(sat, site, first.TCA, first.TCA.Add(time.Hour), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !passes[0].StartClipped || !passes[0].AOS.Equal(first.TCA) {
+		t.Errorf("first pass = %+v, want it cut to the window", passes[0])
+	}
+
+	passes, err = Find(sat, site, start, first.TCA, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := passes[len(passes)-1]; !last.EndClipped || !last.LOS.Equal(first.TCA) {
+		t.Errorf("last pass = %+v, want it cut to the window", last)
+	}
+}
+
+func TestFindGrazing(t *testing.T) {
+	// above 80° the passes last well under a minute, far less than the step
+	opts := Options{MinElevation: 80, Step: 10 * time.Minute}
+	coarse, err := Find(sat, site, start, start.Add(12*time.Hour), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.Step = 5 * time.Second
+	fine, err := Find(sat, site, start, start.Add(12*time.Hour), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coarse) != len(fine) || len(fine) == 0 {
+		t.Fatalf("coarse search found %d passes, fine search %d", len(coarse), len(fine))
+	}
+	for i := range fine {
+		if d := coarse[i].AOS.Sub(fine[i].AOS); d.Abs() > 50*time.Millisecond {
+			t.Errorf("pass %d: AOS %v vs %v", i, coarse[i].AOS, fine[i].AOS)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	p := Pass{
+		AOS: start, TCA: start.Add(5 * time.Minute), LOS: start.Add(10*time.Minute + 500*time.Millisecond),
+		MaxElevation: 45, Duration: 10*time.Minute + 500*time.Millisecond,
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, "json", []Pass{p}); err != nil {
+		t.Fatal(err)
+	}
+	var out []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out[0]["los"] != "2024-03-01T00:10:00.500Z" || out[0]["duration_seconds"] != 600.5 {
+		t.Errorf("Write(json) = %s", buf.String())
+	}
+}
+
+func TestFindVisible(t *testing.T) {
+	standard := -1.8
+	all, err := Find(sat, site, start, start.Add(72*time.Hour), Options{StandardMagnitude: &standard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	visible, err := Find(sat, site, start, start.Add(72*time.Hour), Options{VisibleOnly: true, StandardMagnitude: &standard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only passes in the twilight after sunset or before sunrise are seen:
+	// in daylight the sky is too bright, at midnight the satellite is in the
+	// Earth's shadow
+	if len(visible) == 0 || len(visible) == len(all) {
+		t.Fatalf("%d of %d passes visible", len(visible), len(all))
+	}
+	for _, p := range visible {
+		if !p.Visible || p.VisibleStart.Before(p.AOS) || p.VisibleEnd.After(p.LOS) || p.VisibleEnd.Before(p.VisibleStart) {
+			t.Errorf("visible window %v-%v outside pass %v-%v", p.VisibleStart, p.VisibleEnd, p.AOS, p.LOS)
+		}
+		if el := sun.Elevation(site, p.VisibleStart); el >= DefaultTwilight {
+			t.Errorf("pass at %v visible with the Sun at %.1f°", p.VisibleStart, el)
+		}
+		if shadow, _ := sun.ShadowAt(sat, p.VisibleStart); shadow == sun.Umbra {
+			t.Errorf("pass at %v visible in the umbra", p.VisibleStart)
+		}
+		// lit low in the sky late in twilight, it is still a naked-eye object
+		if math.IsNaN(p.Magnitude) || p.Magnitude < -5 || p.Magnitude > 4 {
+			t.Errorf("pass at %v magnitude %.1f", p.VisibleStart, p.Magnitude)
+		}
+	}
+}
+
+func TestFindTwilight(t *testing.T) {
+	plain, err := Find(sat, site, start, start.Add(72*time.Hour), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range plain {
+		if p.VisibilityChecked || p.Visible || !math.IsNaN(p.Magnitude) {
+			t.Fatalf("visibility worked out unasked: %+v", p)
+		}
+	}
+
+	// a 0° twilight is taken as given, not as the default
+	civil, err := Find(sat, site, start, start.Add(72*time.Hour), Options{VisibleOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	horizon := 0.0
+	sunset, err := Find(sat, site, start, start.Add(72*time.Hour), Options{VisibleOnly: true, Twilight: &horizon})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sunset) < len(civil) {
+		t.Errorf("%d passes visible after sunset, fewer than %d after civil twilight", len(sunset), len(civil))
+	}
+	var early bool
+	for _, p := range sunset {
+		el := sun.Elevation(site, p.VisibleStart)
+		if el >= 0 {
+			t.Errorf("pass at %v visible with the Sun at %.1f°", p.VisibleStart, el)
+		}
+		early = early || el >= DefaultTwilight
+	}
+	if !early {
+		t.Error("no pass became visible before civil twilight ended")
+	}
+}
+
+// polar is an object on the Earth's axis at a height in km, where the
+// Earth's rotation does not move it, so equal heights give exactly equal
+// elevations.
+type polar func(t time.Time) float64
+
+func (p polar) StateAt(t time.Time) (observer.State, error) {
+	return observer.State{Position: observer.Vector{0, 0, p(t)}}, nil
+}
+
+func TestFindGrazingPlateau(t *testing.T) {
+	// a plateau below the mask, but for a bump above it that rises and sets
+	// between two samples, lifting both to the same elevation
+	const low, high = 10000, 12000
+	site := observer.Observer{Latitude: 45}
+	bump := start.Add(time.Hour + 15*time.Second)
+	flat := polar(func(t time.Time) float64 {
+		if d := math.Abs(t.Sub(bump).Seconds()); d < 20 {
+			return high - (high-low)*d/20
+		}
+		return low
+	})
+	mask := site.Look(observer.State{Position: observer.Vector{0, 0, (low + high) / 2}}, start).Elevation
+
+	passes, err := Find(flat, site, start, start.Add(2*time.Hour), Options{MinElevation: mask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(passes) != 1 {
+		t.Fatalf("got %d passes, want the bump once: %+v", len(passes), passes)
+	}
+	if p := passes[0]; p.AOS.After(bump) || p.LOS.Before(bump) {
+		t.Errorf("pass %v-%v misses the bump at %v", p.AOS, p.LOS, bump)
+	}
+}
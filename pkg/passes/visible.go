@@ -0,0 +1,49 @@
+package passes
+
+import (
+	"math"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/sun"
+)
+
+// visibleStep is how finely a pass is sampled for visibility; eyes and
+// twilight do not need finer.
+const visibleStep = time.Second
+
+// visibility fills in when during the pass the satellite is sunlit, or in
+// penumbra, while the observer's Sun is below the twilight elevation.
+func (f finder) visibility(p *Pass) error {
+	twilight := float64(DefaultTwilight)
+	if f.opts.Twilight != nil {
+		twilight = *f.opts.Twilight
+	}
+	p.VisibilityChecked = true
+	for t := p.AOS; ; t = t.Add(visibleStep) {
+		if t.After(p.LOS) {
+			t = p.LOS
+		}
+		if sun.Elevation(f.o, t) < twilight {
+			s, err := f.p.StateAt(t)
+			if err != nil {
+				return err
+			}
+			if sun.ShadowOf(s.Position, sun.Position(t)) != sun.Umbra {
+				if !p.Visible {
+					p.Visible, p.VisibleStart = true, t
+				}
+				p.VisibleEnd = t
+				if f.opts.StandardMagnitude != nil {
+					look := f.o.Look(s, t)
+					m := sun.Magnitude(*f.opts.StandardMagnitude, look.Range, sun.PhaseAngle(s.Position, f.o, t))
+					if math.IsNaN(p.Magnitude) || m < p.Magnitude {
+						p.Magnitude = m
+					}
+				}
+			}
+		}
+		if !t.Before(p.LOS) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Package satcat reads the satellite catalog (SATCAT) published by CelesTrak,
+// which holds what element sets do not: owner, launch and decay dates,
+// object type, operational status and radar cross section.
+package satcat
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Object types.
+const (
+	TypePayload    = "PAY"
+	TypeRocketBody = "R/B"
+	TypeDebris     = "DEB"
+	TypeUnknown    = "UNK"
+)
+
+// Operational status codes.
+const (
+	StatusOperational          = "+"
+	StatusNonoperational       = "-"
+	StatusPartiallyOperational = "P"
+	StatusBackup               = "B"
+	StatusSpare                = "S"
+	StatusExtendedMission      = "X"
+	StatusDecayed              = "D"
+	StatusUnknown              = "?"
+)
+
+var typeNames = map[string]string{
+	TypePayload:    "Payload",
+	TypeRocketBody: "Rocket body",
+	TypeDebris:     "Debris",
+	TypeUnknown:    "Unknown",
+}
+
+var statusNames = map[string]string{
+	StatusOperational:          "Operational",
+	StatusNonoperational:       "Nonoperational",
+	StatusPartiallyOperational: "Partially operational",
+	StatusBackup:               "Backup",
+	StatusSpare:                "Spare",
+	StatusExtendedMission:      "Extended mission",
+	StatusDecayed:              "Decayed",
+	StatusUnknown:              "Unknown",
+}
+
+// Record is one object of the catalog.
+type Record struct {
+	Name        string
+	ObjectID    string // international designator, e.g. 1998-067A
+	NoradID     int
+	ObjectType  string // one of the Type constants
+	OpsStatus   string // one of the Status constants, or empty
+	Owner       string // owner or country code, e.g. US, PRC, ISS
+	LaunchDate  time.Time
+	LaunchSite  string
+	DecayDate   time.Time // zero while in orbit
+	Period      float64   // minutes
+	Inclination float64   // degrees
+	Apogee      float64   // km
+	Perigee     float64   // km
+	RCS         float64   // radar cross section in m², NaN when unknown
+	DataStatus  string
+	OrbitCenter string
+	OrbitType   string
+}
+
+// TypeName spells out the object type, e.g. "Rocket body".
+func (r Record) TypeName() string {
+	if name, ok := typeNames[r.ObjectType]; ok {
+		return name
+	}
+	return r.ObjectType
+}
+
+// StatusName spells out the operational status, e.g. "Operational".
+func (r Record) StatusName() string {
+	if name, ok := statusNames[r.OpsStatus]; ok {
+		return name
+	}
+	return r.OpsStatus
+}
+
+// Operational reports whether the object is fully or partly working,
+// including backups, spares and extended missions.
+func (r Record) Operational() bool {
+	switch r.OpsStatus {
+	case StatusOperational, StatusPartiallyOperational, StatusBackup, StatusSpare, StatusExtendedMission:
+		return true
+	}
+	return false
+}
+
+// Decayed reports whether the object has reentered.
+func (r Record) Decayed() bool {
+	return r.OpsStatus == StatusDecayed || !r.DecayDate.IsZero()
+}
+
+// RCSSize classifies the radar cross section the way Space-Track does:
+// SMALL below 0.1 m², MEDIUM below 1 m² and LARGE above. It is empty when
+// the cross section is unknown.
+func (r Record) RCSSize() string {
+	switch {
+	case math.IsNaN(r.RCS):
+		return ""
+	case r.RCS < 0.1:
+		return "SMALL"
+	case r.RCS < 1:
+		return "MEDIUM"
+	}
+	return "LARGE"
+}
+
+// requiredColumns are the SATCAT CSV columns Read cannot do without; the
+// others are left empty when missing.
+var requiredColumns = []string{"OBJECT_NAME", "OBJECT_ID", "NORAD_CAT_ID"}
+
+// Read parses a SATCAT in CelesTrak's CSV format. Columns are found by their
+// header, so their order does not matter.
+func Read(r io.Reader) ([]Record, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty SATCAT")
+		}
+		return nil, err
+	}
+	col := map[string]int{}
+	for i, name := range header {
+		col[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("SATCAT has no %s column", name)
+		}
+	}
+
+	var records []Record
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := col[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		line, _ := cr.FieldPos(0)
+		rec := Record{
+			Name:        field("OBJECT_NAME"),
+			ObjectID:    field("OBJECT_ID"),
+			ObjectType:  field("OBJECT_TYPE"),
+			OpsStatus:   field("OPS_STATUS_CODE"),
+			Owner:       field("OWNER"),
+			LaunchSite:  field("LAUNCH_SITE"),
+			Period:      parseFloat(field("PERIOD")),
+			Inclination: parseFloat(field("INCLINATION")),
+			Apogee:      parseFloat(field("APOGEE")),
+			Perigee:     parseFloat(field("PERIGEE")),
+			RCS:         parseFloat(field("RCS")),
+			DataStatus:  field("DATA_STATUS_CODE"),
+			OrbitCenter: field("ORBIT_CENTER"),
+			OrbitType:   field("ORBIT_TYPE"),
+		}
+		if rec.NoradID, err = strconv.Atoi(field("NORAD_CAT_ID")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid NORAD_CAT_ID: %w", line, err)
+		}
+		if rec.LaunchDate, err = parseDate(field("LAUNCH_DATE")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid LAUNCH_DATE: %w", line, err)
+		}
+		if rec.DecayDate, err = parseDate(field("DECAY_DATE")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid DECAY_DATE: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+}
+
+// parseFloat parses an optional number, returning NaN for an empty field.
+func parseFloat(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return v
+}
+
+// FormatDate writes a SATCAT date as YYYY-MM-DD, or "" for a missing one.
+func FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+// Catalog is a SATCAT indexed by NORAD ID.
+type Catalog struct {
+	Records []Record // sorted by NORAD ID
+}
+
+// NewCatalog indexes records.
+func NewCatalog(records []Record) *Catalog {
+	sorted := append([]Record(nil), records...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].NoradID < sorted[j].NoradID })
+	return &Catalog{Records: sorted}
+}
+
+// Lookup returns the record of a NORAD ID.
+func (c *Catalog) Lookup(noradID int) (Record, bool) {
+	if c == nil {
+		return Record{}, false
+	}
+	i := sort.Search(len(c.Records), func(i int) bool { return c.Records[i].NoradID >= noradID })
+	if i < len(c.Records) && c.Records[i].NoradID == noradID {
+		return c.Records[i], true
+	}
+	return Record{}, false
+}
+
+// Filter selects records by object type, owner and status. Empty lists do
+// not filter; a record must match one entry of every list that is set.
+type Filter struct {
+	ObjectTypes []string // type codes or names: payload, rocket-body, debris, unknown
+	Owners      []string // owner or country codes, e.g. US, PRC
+	Statuses    []string // status codes or operational, nonoperational, decayed, in-orbit
+}
+
+// ParseFilter builds a Filter from comma-separated lists of object types,
+// owners and statuses, as given on the command line or in a query string.
+func ParseFilter(objectTypes, owners, statuses string) Filter {
+	return Filter{
+		ObjectTypes: splitList(objectTypes),
+		Owners:      splitList(owners),
+		Statuses:    splitList(statuses),
+	}
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// IsZero reports whether f filters nothing.
+func (f Filter) IsZero() bool {
+	return len(f.ObjectTypes) == 0 && len(f.Owners) == 0 && len(f.Statuses) == 0
+}
+
+// Match reports whether r passes the filter.
+func (f Filter) Match(r Record) bool {
+	return matchAny(f.ObjectTypes, func(t string) bool { return ParseObjectType(t) == r.ObjectType }) &&
+		matchAny(f.Owners, func(o string) bool { return strings.EqualFold(o, r.Owner) }) &&
+		matchAny(f.Statuses, func(s string) bool { return matchStatus(s, r) })
+}
+
+// Filter returns the records matching f.
+func (c *Catalog) Filter(f Filter) []Record {
+	var matches []Record
+	for _, r := range c.Records {
+		if f.Match(r) {
+			matches = append(matches, r)
+		}
+	}
+	return matches
+}
+
+func matchAny(values []string, match func(string) bool) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseObjectType turns a type name such as "payload", "rb" or "debris" into
+// its code. Codes and unknown values are returned upper-cased.
+func ParseObjectType(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "pay", "payload":
+		return TypePayload
+	case "r/b", "rb", "rocket-body", "rocket body", "rocket":
+		return TypeRocketBody
+	case "deb", "debris":
+		return TypeDebris
+	case "unk", "unknown":
+		return TypeUnknown
+	}
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+func matchStatus(s string, r Record) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "operational", "active":
+		return r.Operational()
+	case "nonoperational", "inactive":
+		return r.OpsStatus == StatusNonoperational
+	case "decayed":
+		return r.Decayed()
+	case "in-orbit", "on-orbit":
+		return !r.Decayed()
+	}
+	return strings.EqualFold(s, r.OpsStatus)
+}
@@ -0,0 +1,92 @@
+package satcat
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sample = `OBJECT_NAME,OBJECT_ID,NORAD_CAT_ID,OBJECT_TYPE,OPS_STATUS_CODE,OWNER,LAUNCH_DATE,LAUNCH_SITE,DECAY_DATE,PERIOD,INCLINATION,APOGEE,PERIGEE,RCS,DATA_STATUS_CODE,ORBIT_CENTER,ORBIT_TYPE
+ISS (ZARYA),1998-067A,25544,PAY,+,ISS,1998-11-20,TYMSC,,92.87,51.64,422,417,399.0524,,EA,ORB
+SL-1 R/B,1957-001A,1,R/B,D,CIS,1957-10-04,TYMSC,1957-12-01,96.19,65.10,938,214,20.4200,,EA,IMP
+FENGYUN 1C DEB,1999-025AAA,29735,DEB,,PRC,1999-05-10,TSC,,101.51,98.75,869,836,,,EA,ORB
+NOAA 17,2002-032A,27453,PAY,-,US,2002-06-24,AFWTR,,101.16,98.37,816,801,2.4260,,EA,ORB
+`
+
+func TestRead(t *testing.T) {
+	records, err := Read(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("got %d records, want 4", len(records))
+	}
+
+	iss := records[0]
+	if iss.NoradID != 25544 || iss.ObjectID != "1998-067A" || iss.Owner != "ISS" || iss.TypeName() != "Payload" {
+		t.Errorf("ISS = %+v", iss)
+	}
+	if !iss.LaunchDate.Equal(time.Date(1998, 11, 20, 0, 0, 0, 0, time.UTC)) || !iss.DecayDate.IsZero() {
+		t.Errorf("ISS dates = %v, %v", iss.LaunchDate, iss.DecayDate)
+	}
+	if !iss.Operational() || iss.Decayed() || iss.RCSSize() != "LARGE" {
+		t.Errorf("ISS status: operational %v, decayed %v, RCS %s", iss.Operational(), iss.Decayed(), iss.RCSSize())
+	}
+	if !records[1].Decayed() || records[1].StatusName() != "Decayed" {
+		t.Errorf("SL-1 R/B should be decayed: %+v", records[1])
+	}
+	if !math.IsNaN(records[2].RCS) || records[2].RCSSize() != "" {
+		t.Errorf("debris RCS = %v, want unknown", records[2].RCS)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"OBJECT_NAME,OBJECT_ID\nISS,1998-067A\n",
+		"OBJECT_NAME,OBJECT_ID,NORAD_CAT_ID\nISS,1998-067A,ISS\n",
+	} {
+		if _, err := Read(strings.NewReader(data)); err == nil {
+			t.Errorf("Read(%q) succeeded, want an error", data)
+		}
+	}
+}
+
+func TestCatalogFilter(t *testing.T) {
+	records, err := Read(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cat := NewCatalog(records)
+	if rec, ok := cat.Lookup(27453); !ok || rec.Name != "NOAA 17" {
+		t.Errorf("Lookup(27453) = %+v, %v", rec, ok)
+	}
+	if _, ok := cat.Lookup(99999); ok {
+		t.Error("Lookup(99999) found a record")
+	}
+
+	tests := []struct {
+		filter Filter
+		want   string
+	}{
+		{Filter{}, "SL-1 R/B,ISS (ZARYA),NOAA 17,FENGYUN 1C DEB"},
+		{Filter{ObjectTypes: []string{"payload"}}, "ISS (ZARYA),NOAA 17"},
+		{Filter{ObjectTypes: []string{"rb", "debris"}}, "SL-1 R/B,FENGYUN 1C DEB"},
+		{Filter{Owners: []string{"us", "PRC"}}, "NOAA 17,FENGYUN 1C DEB"},
+		{Filter{Statuses: []string{"operational"}}, "ISS (ZARYA)"},
+		{Filter{Statuses: []string{"in-orbit"}, ObjectTypes: []string{"PAY"}}, "ISS (ZARYA),NOAA 17"},
+		{Filter{Statuses: []string{"-"}}, "NOAA 17"},
+		{ParseFilter(" payload, ,rb", "", ""), "SL-1 R/B,ISS (ZARYA),NOAA 17"},
+		{ParseFilter("", "", ""), "SL-1 R/B,ISS (ZARYA),NOAA 17,FENGYUN 1C DEB"},
+	}
+	for _, tt := range tests {
+		var names []string
+		for _, rec := range cat.Filter(tt.filter) {
+			names = append(names, rec.Name)
+		}
+		if got := strings.Join(names, ","); got != tt.want {
+			t.Errorf("Filter(%+v) = %s, want %s", tt.filter, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/output"
+	"github.com/Mohammed-Ashour/tlego/pkg/satcat"
+)
+
+// SortKeys are the keys Sort accepts.
+var SortKeys = []string{"score", "name", "norad", "epoch", "inclination", "period", "apogee", "perigee"}
+
+// Sort orders results by key, ascending except for score, which puts the
+// best matches first. reverse flips the order. Ties keep their order.
+func Sort(results []Result, key string, reverse bool) error {
+	var less func(a, b Result) bool
+	switch strings.ToLower(key) {
+	case "", "score":
+		less = func(a, b Result) bool { return a.Score > b.Score }
+	case "name":
+		less = func(a, b Result) bool { return strings.ToLower(a.TLE.Name) < strings.ToLower(b.TLE.Name) }
+	case "norad":
+		less = func(a, b Result) bool { return a.TLE.Elements.CatalogNumber < b.TLE.Elements.CatalogNumber }
+	case "epoch":
+		less = func(a, b Result) bool { return a.TLE.Elements.Epoch.Before(b.TLE.Elements.Epoch) }
+	case "inclination":
+		less = func(a, b Result) bool { return a.TLE.Elements.Inclination < b.TLE.Elements.Inclination }
+	case "period":
+		less = func(a, b Result) bool { return a.Orbit.Period < b.Orbit.Period }
+	case "apogee":
+		less = func(a, b Result) bool { return a.Orbit.ApogeeAltitude < b.Orbit.ApogeeAltitude }
+	case "perigee":
+		less = func(a, b Result) bool { return a.Orbit.PerigeeAltitude < b.Orbit.PerigeeAltitude }
+	default:
+		return fmt.Errorf("unknown sort key %q, expected one of %s", key, strings.Join(SortKeys, ", "))
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if reverse {
+			return less(results[j], results[i])
+		}
+		return less(results[i], results[j])
+	})
+	return nil
+}
+
+// resultJSON is the JSON and CSV form of a Result.
+type resultJSON struct {
+	NoradID     int      `json:"norad_id"`
+	Name        string   `json:"name"`
+	ObjectID    string   `json:"object_id"`
+	Epoch       string   `json:"epoch"`
+	Inclination float64  `json:"inclination_deg"`
+	Period      float64  `json:"period_minutes"`
+	Apogee      float64  `json:"apogee_altitude_km"`
+	Perigee     float64  `json:"perigee_altitude_km"`
+	Regime      string   `json:"regime"`
+	Groups      []string `json:"groups,omitempty"`
+	ObjectType  string   `json:"object_type,omitempty"`
+	Owner       string   `json:"owner,omitempty"`
+	OpsStatus   string   `json:"ops_status,omitempty"`
+	LaunchDate  string   `json:"launch_date,omitempty"`
+	DecayDate   string   `json:"decay_date,omitempty"`
+	RCS         *float64 `json:"rcs,omitempty"`
+	Score       float64  `json:"score"`
+	Line1       string   `json:"line1,omitempty"`
+	Line2       string   `json:"line2,omitempty"`
+}
+
+func toJSON(r Result) resultJSON {
+	e := r.TLE.Elements
+	out := resultJSON{
+		NoradID:     e.CatalogNumber,
+		Name:        r.TLE.Name,
+		ObjectID:    e.ObjectID(),
+		Epoch:       e.Epoch.UTC().Format(time.RFC3339),
+		Inclination: e.Inclination,
+		Period:      r.Orbit.Period,
+		Apogee:      r.Orbit.ApogeeAltitude,
+		Perigee:     r.Orbit.PerigeeAltitude,
+		Regime:      string(r.Orbit.Regime),
+		Groups:      r.Groups,
+		Score:       r.Score,
+		Line1:       r.TLE.Line1.LineString,
+		Line2:       r.TLE.Line2.LineString,
+	}
+	if rec := r.SATCAT; rec != nil {
+		out.ObjectType = rec.ObjectType
+		out.Owner = rec.Owner
+		out.OpsStatus = rec.OpsStatus
+		out.LaunchDate = satcat.FormatDate(rec.LaunchDate)
+		out.DecayDate = satcat.FormatDate(rec.DecayDate)
+		if !math.IsNaN(rec.RCS) {
+			out.RCS = &rec.RCS
+		}
+	}
+	return out
+}
+
+// Write writes results in format: table, json or csv. CSV groups are
+// separated by semicolons.
+func Write(w io.Writer, format string, results []Result) error {
+	table := [][]string{{"NORAD ID", "NAME", "OBJECT ID", "TYPE", "OWNER", "STATUS", "INCL (°)", "PERIOD (min)",
+		"APOGEE (km)", "PERIGEE (km)", "GROUPS", "SCORE"}}
+	records := [][]string{{"norad_id", "name", "object_id", "epoch", "inclination_deg", "period_minutes",
+		"apogee_altitude_km", "perigee_altitude_km", "regime", "groups", "object_type", "owner", "ops_status",
+		"launch_date", "decay_date", "score"}}
+	out := make([]resultJSON, 0, len(results))
+	for _, r := range results {
+		j := toJSON(r)
+		var status string
+		if rec := r.SATCAT; rec != nil {
+			status = rec.StatusName()
+		}
+		table = append(table, []string{
+			strconv.Itoa(j.NoradID), j.Name, j.ObjectID, j.ObjectType, j.Owner, status,
+			fmt.Sprintf("%.2f", j.Inclination), fmt.Sprintf("%.1f", j.Period),
+			fmt.Sprintf("%.0f", j.Apogee), fmt.Sprintf("%.0f", j.Perigee),
+			strings.Join(j.Groups, ", "), fmt.Sprintf("%.2f", j.Score),
+		})
+		records = append(records, []string{
+			strconv.Itoa(j.NoradID), j.Name, j.ObjectID, j.Epoch,
+			output.FormatFloat(j.Inclination), output.FormatFloat(j.Period),
+			output.FormatFloat(j.Apogee), output.FormatFloat(j.Perigee),
+			j.Regime, strings.Join(j.Groups, ";"), j.ObjectType, j.Owner, j.OpsStatus,
+			j.LaunchDate, j.DecayDate, output.FormatFloat(j.Score),
+		})
+		out = append(out, j)
+	}
+	return output.Write(w, format, output.Data{Table: table, CSV: records, JSON: out})
+}
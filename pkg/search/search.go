@@ -0,0 +1,243 @@
+// Package search filters and ranks satellites by name and orbital elements.
+package search
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/satcat"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// minSimilarity is how close a misspelt name must be to match, as the share
+// of characters that need no edit.
+const minSimilarity = 0.75
+
+// Candidate is a satellite that can be searched, with its SATCAT record
+// when one is known.
+type Candidate struct {
+	TLE    tle.TLE
+	Groups []string
+	SATCAT *satcat.Record
+}
+
+// Result is a matching satellite. Score ranks name matches from 1 for an
+// exact match down to 0; it is 1 when the query has no name.
+type Result struct {
+	Candidate
+	Orbit tle.OrbitParameters
+	Score float64
+}
+
+// Range is an inclusive interval. A side left as NaN is open.
+type Range struct {
+	Min, Max float64
+}
+
+// AnyRange matches every value.
+var AnyRange = Range{math.NaN(), math.NaN()}
+
+// ParseRange parses "MIN:MAX", where either side may be empty, e.g. "97:99",
+// ":2000" or "35000:". A single number matches that value only.
+func ParseRange(s string) (Range, error) {
+	if s == "" {
+		return AnyRange, nil
+	}
+	lo, hi, found := strings.Cut(s, ":")
+	if !found {
+		hi = lo
+	}
+	r := AnyRange
+	var err error
+	if lo = strings.TrimSpace(lo); lo != "" {
+		if r.Min, err = strconv.ParseFloat(lo, 64); err != nil {
+			return Range{}, fmt.Errorf("invalid range %q: %w", s, err)
+		}
+	}
+	if hi = strings.TrimSpace(hi); hi != "" {
+		if r.Max, err = strconv.ParseFloat(hi, 64); err != nil {
+			return Range{}, fmt.Errorf("invalid range %q: %w", s, err)
+		}
+	}
+	if r.Min > r.Max {
+		return Range{}, fmt.Errorf("invalid range %q: minimum above maximum", s)
+	}
+	return r, nil
+}
+
+// Contains reports whether v lies in the range.
+func (r Range) Contains(v float64) bool {
+	return (math.IsNaN(r.Min) || v >= r.Min) && (math.IsNaN(r.Max) || v <= r.Max)
+}
+
+// Query selects satellites. Zero-valued fields do not filter; ranges must be
+// set to AnyRange, as NewQuery does, to match everything.
+type Query struct {
+	Name           string // fuzzy matched against the satellite name
+	NoradID        Range
+	IntlDesignator string // prefix of the COSPAR designator, e.g. 1998-067 or 2024-
+	LaunchYear     int
+	Inclination    Range // degrees
+	Period         Range // minutes
+	Apogee         Range // km above the equatorial radius
+	Perigee        Range // km above the equatorial radius
+	Group          string
+	SATCAT         satcat.Filter // object type, owner and status; excludes satellites without a record
+}
+
+// NewQuery returns a query matching every satellite.
+func NewQuery() Query {
+	return Query{
+		NoradID:     AnyRange,
+		Inclination: AnyRange,
+		Period:      AnyRange,
+		Apogee:      AnyRange,
+		Perigee:     AnyRange,
+	}
+}
+
+// Search returns the candidates matching q, best name matches first and then
+// by NORAD ID.
+func Search(candidates []Candidate, q Query) []Result {
+	var results []Result
+	for _, c := range candidates {
+		result, ok := q.match(c)
+		if ok {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].TLE.Elements.CatalogNumber < results[j].TLE.Elements.CatalogNumber
+	})
+	return results
+}
+
+func (q Query) match(c Candidate) (Result, bool) {
+	e := c.TLE.Elements
+	orbit, err := c.TLE.OrbitParameters()
+	if err != nil {
+		return Result{}, false
+	}
+	switch {
+	case !q.NoradID.Contains(float64(e.CatalogNumber)),
+		q.IntlDesignator != "" && !strings.HasPrefix(e.ObjectID(), strings.ToUpper(q.IntlDesignator)),
+		q.LaunchYear != 0 && e.LaunchYear() != q.LaunchYear,
+		!q.Inclination.Contains(e.Inclination),
+		!q.Period.Contains(orbit.Period),
+		!q.Apogee.Contains(orbit.ApogeeAltitude),
+		!q.Perigee.Contains(orbit.PerigeeAltitude),
+		q.Group != "" && !inGroup(c.Groups, q.Group),
+		!q.SATCAT.IsZero() && (c.SATCAT == nil || !q.SATCAT.Match(*c.SATCAT)):
+		return Result{}, false
+	}
+
+	score := 1.0
+	if q.Name != "" {
+		score = NameScore(q.Name, c.TLE.Name)
+		if score == 0 {
+			return Result{}, false
+		}
+	}
+	return Result{Candidate: c, Orbit: orbit, Score: score}, true
+}
+
+func inGroup(groups []string, group string) bool {
+	for _, g := range groups {
+		if strings.EqualFold(g, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// NameScore rates how well query matches a satellite name, ignoring case and
+// punctuation: 1 for the same name, 0.95 when the name starts with the query
+// ("ISS" for "ISS (ZARYA)"), 0.9 when it contains it as whole words, 0.8 when
+// it contains it at all, even with spaces left out, and up to 0.7 for names
+// within a few typos of it. Names too different score 0.
+func NameScore(query, name string) float64 {
+	q := normalize(query)
+	n := normalize(name)
+	if q == "" {
+		return 0
+	}
+	switch {
+	case n == q:
+		return 1
+	case strings.HasPrefix(n, q+" "):
+		return 0.95
+	case strings.Contains(" "+n+" ", " "+q+" "):
+		return 0.9
+	case strings.Contains(strings.ReplaceAll(n, " ", ""), strings.ReplaceAll(q, " ", "")):
+		return 0.8
+	}
+
+	// compare the query with every run of as many words in the name, and with
+	// the start of that run to allow for names typed partially
+	qWords, qRunes := len(strings.Fields(q)), len([]rune(q))
+	words := strings.Fields(n)
+	best := 0.0
+	for i := 0; i+qWords <= len(words); i++ {
+		window := strings.Join(words[i:i+qWords], " ")
+		best = math.Max(best, similarity(q, window))
+		if runes := []rune(window); len(runes) > qRunes {
+			best = math.Max(best, similarity(q, string(runes[:qRunes])))
+		}
+	}
+	if best < minSimilarity {
+		return 0
+	}
+	return 0.7 * best
+}
+
+// normalize lowercases s and turns punctuation into single spaces.
+func normalize(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// similarity is 1 minus the edit distance between a and b relative to the
+// longer of the two, in runes.
+func similarity(a, b string) float64 {
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(a, b))/float64(longest)
+}
+
+// editDistance is the Damerau-Levenshtein distance (with adjacent
+// transpositions) between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
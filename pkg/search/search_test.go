@@ -0,0 +1,177 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/satcat"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+const catalog = `ISS (ZARYA)
+1 25544U 98067A   24057.91666667  .00016717  00000-0  10270-3 0  9005
+2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537
+CSS (TIANHE)
+1 48274U 21035A   24057.50000000  .00020000  00000-0  22000-3 0  9990
+2 48274  41.4700 100.0000 0005000  90.0000 270.0000 15.60000000150000
+STARLINK-1007
+1 44713U 19074A   24057.50000000  .00001000  00000-0  80000-4 0  9990
+2 44713  53.0540 100.0000 0001400  90.0000 270.0000 15.06400000240000
+GOES 16
+1 41866U 16071A   24057.50000000 -.00000100  00000-0  00000-0 0  9990
+2 41866   0.0500 100.0000 0001000  90.0000 270.0000  1.00270000 26000
+`
+
+func candidates(t *testing.T) []Candidate {
+	tles, err := tle.ReadTLEs(strings.NewReader(catalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := [][]string{{"Space Stations"}, {"Space Stations"}, {"Starlink"}, {"GOES", "Weather"}}
+	var cs []Candidate
+	for i, tle := range tles {
+		cs = append(cs, Candidate{TLE: tle, Groups: groups[i]})
+	}
+	return cs
+}
+
+func names(results []Result) string {
+	var out []string
+	for _, r := range results {
+		out = append(out, r.TLE.Name)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestNameScore(t *testing.T) {
+	tests := []struct {
+		query, name string
+		want        float64
+	}{
+		{"ISS (ZARYA)", "iss zarya", 1},
+		{"ISS", "ISS (ZARYA)", 0.95},
+		{"zarya", "ISS (ZARYA)", 0.9},
+		{"link", "STARLINK-1007", 0.8},
+		{"cubesat", "ISS (ZARYA)", 0},
+	}
+	for _, tt := range tests {
+		if got := NameScore(tt.query, tt.name); got != tt.want {
+			t.Errorf("NameScore(%q, %q) = %v, want %v", tt.query, tt.name, got, tt.want)
+		}
+	}
+	for _, typo := range []string{"starlnik", "strlink", "tianhee", "goes16"} {
+		if NameScore(typo, "STARLINK-1007")+NameScore(typo, "CSS (TIANHE)")+NameScore(typo, "GOES 16") == 0 {
+			t.Errorf("%q matched nothing", typo)
+		}
+	}
+	// a partly typed name with a typo scores the same in any script
+	if latin, cyrillic := NameScore("strle", "STRELA 3M"), NameScore("стрле", "СТРЕЛА 3М"); latin == 0 || cyrillic != latin {
+		t.Errorf("NameScore() = %v in Cyrillic, %v in Latin", cyrillic, latin)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	cs := candidates(t)
+
+	q := NewQuery()
+	q.Name = "iss"
+	if got := names(Search(cs, q)); got != "ISS (ZARYA)" {
+		t.Errorf("name search = %s", got)
+	}
+
+	q = NewQuery()
+	q.Group = "space stations"
+	q.Inclination, _ = ParseRange("50:")
+	if got := names(Search(cs, q)); got != "ISS (ZARYA)" {
+		t.Errorf("group and inclination search = %s", got)
+	}
+
+	q = NewQuery()
+	q.Apogee, _ = ParseRange("30000:")
+	if got := names(Search(cs, q)); got != "GOES 16" {
+		t.Errorf("apogee search = %s", got)
+	}
+
+	q = NewQuery()
+	q.LaunchYear = 2021
+	if got := names(Search(cs, q)); got != "CSS (TIANHE)" {
+		t.Errorf("launch year search = %s", got)
+	}
+
+	q = NewQuery()
+	q.IntlDesignator = "1998-067"
+	q.NoradID, _ = ParseRange("25000:26000")
+	if got := names(Search(cs, q)); got != "ISS (ZARYA)" {
+		t.Errorf("designator search = %s", got)
+	}
+
+	results := Search(cs, NewQuery())
+	if err := Sort(results, "period", true); err != nil || names(results) != "GOES 16,STARLINK-1007,CSS (TIANHE),ISS (ZARYA)" {
+		t.Errorf("sorted by period = %s, %v", names(results), err)
+	}
+	if err := Sort(results, "mass", false); err == nil {
+		t.Error("expected an error for an unknown sort key")
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	r, err := ParseRange("90:100")
+	if err != nil || !r.Contains(95) || r.Contains(101) {
+		t.Errorf("ParseRange(90:100) = %+v, %v", r, err)
+	}
+	r, err = ParseRange(":2000")
+	if err != nil || !r.Contains(-5) || r.Contains(2001) {
+		t.Errorf("ParseRange(:2000) = %+v, %v", r, err)
+	}
+	for _, bad := range []string{"a:b", "10:5"} {
+		if _, err := ParseRange(bad); err == nil {
+			t.Errorf("ParseRange(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	results := Search(candidates(t), NewQuery())
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "json", results); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded) != 4 || decoded[0]["norad_id"] != 25544.0 {
+		t.Errorf("unexpected JSON output %s: %v", buf.String(), err)
+	}
+
+	buf.Reset()
+	if err := Write(&buf, "csv", results); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[4], "48274,CSS (TIANHE),2021-035A,") {
+		t.Errorf("unexpected CSV output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := Write(&buf, "table", results); err != nil || !strings.Contains(buf.String(), "GOES, Weather") {
+		t.Errorf("unexpected table output:\n%s", buf.String())
+	}
+}
+
+func TestSearchSATCAT(t *testing.T) {
+	cs := candidates(t)
+	cs[0].SATCAT = &satcat.Record{NoradID: 25544, ObjectType: satcat.TypePayload, Owner: "ISS", OpsStatus: satcat.StatusOperational}
+	cs[1].SATCAT = &satcat.Record{NoradID: 48274, ObjectType: satcat.TypePayload, Owner: "PRC", OpsStatus: satcat.StatusOperational}
+
+	q := NewQuery()
+	q.SATCAT = satcat.Filter{Owners: []string{"prc"}}
+	if got := names(Search(cs, q)); got != "CSS (TIANHE)" {
+		t.Errorf("owner PRC: got %s", got)
+	}
+	// satellites without a SATCAT record never pass a SATCAT filter
+	q.SATCAT = satcat.Filter{ObjectTypes: []string{"payload"}}
+	if got := names(Search(cs, q)); got != "ISS (ZARYA),CSS (TIANHE)" {
+		t.Errorf("payloads: got %s", got)
+	}
+}
@@ -1,22 +1,58 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/satellite"
 	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
 	"github.com/Mohammed-Ashour/tlego/pkg/logger"
+	"github.com/Mohammed-Ashour/tlego/pkg/satcat"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
 	"github.com/Mohammed-Ashour/tlego/pkg/utils"
 )
 
-// Satellite represents a simple satellite model
+// Satellite represents a simple satellite model, with its SATCAT metadata
+// when the catalog is available
 type Satellite struct {
-	Name    string `json:"name"`
-	NORADID string `json:"norad_id"`
+	Name       string `json:"name"`
+	NORADID    string `json:"norad_id"`
+	ObjectType string `json:"object_type,omitempty"`
+	Owner      string `json:"owner,omitempty"`
+	OpsStatus  string `json:"ops_status,omitempty"`
+	LaunchDate string `json:"launch_date,omitempty"`
+	DecayDate  string `json:"decay_date,omitempty"`
+}
+
+// satcatCache keeps the parsed SATCAT between requests; it is reloaded once
+// older than the client's SATCATMaxAge, and the client decides whether that
+// needs a download.
+var satcatCache struct {
+	mu       sync.Mutex
+	catalog  *satcat.Catalog
+	loadedAt time.Time
+}
+
+func loadSATCAT(ctx context.Context) (*satcat.Catalog, error) {
+	satcatCache.mu.Lock()
+	defer satcatCache.mu.Unlock()
+	client := celestrak.NewClient()
+	if satcatCache.catalog != nil && time.Since(satcatCache.loadedAt) < client.SATCATMaxAge {
+		return satcatCache.catalog, nil
+	}
+	catalog, err := client.GetSATCAT(ctx)
+	if err != nil {
+		return nil, err
+	}
+	satcatCache.catalog, satcatCache.loadedAt = catalog, time.Now()
+	return catalog, nil
 }
 
 // listSatellitesHandler provides a list of satellites
@@ -36,7 +72,9 @@ func listGroupsHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(groups)
 }
 
-// /api/satellites?group=GROUP_NAME returns satellites for a specific group
+// /api/satellites?group=GROUP_NAME returns satellites for a specific group,
+// optionally filtered by SATCAT fields with type, country and status
+// (comma-separated, e.g. type=payload&country=US,PRC&status=operational)
 func listSatellitesHandler(w http.ResponseWriter, r *http.Request) {
 	config, err := celestrak.ReadCelestrakConfig()
 	if err != nil {
@@ -48,17 +86,42 @@ func listSatellitesHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Missing group parameter", http.StatusBadRequest)
 		return
 	}
-	tles, err := celestrak.GetSatelliteGroupTLEs(groupName, config)
+	tles, err := celestrak.NewClient().GetSatelliteGroupTLEs(r.Context(), groupName, config)
 	if err != nil {
-		http.Error(w, "Unable to load satellites", http.StatusInternalServerError)
+		logger.Error("Unable to load satellites", "group", groupName, "error", err)
+		http.Error(w, "Unable to load satellites", fetchErrorStatus(err))
 		return
 	}
+	query := r.URL.Query()
+	filter := satcat.ParseFilter(query.Get("type"), query.Get("country"), query.Get("status"))
+	catalog, err := loadSATCAT(r.Context())
+	if err != nil {
+		if !filter.IsZero() {
+			logger.Error("Unable to load SATCAT", "error", err)
+			http.Error(w, "Unable to load SATCAT", fetchErrorStatus(err))
+			return
+		}
+		logger.Warn("Listing satellites without SATCAT data", "error", err)
+	}
+
 	satellites := make([]Satellite, 0, len(tles))
 	for _, t := range tles {
-		satellites = append(satellites, Satellite{
+		entry := Satellite{
 			Name:    t.Name,
 			NORADID: t.NoradID,
-		})
+		}
+		rec, ok := catalog.Lookup(t.Elements.CatalogNumber)
+		if !filter.IsZero() && (!ok || !filter.Match(rec)) {
+			continue
+		}
+		if ok {
+			entry.ObjectType = rec.ObjectType
+			entry.Owner = rec.Owner
+			entry.OpsStatus = rec.OpsStatus
+			entry.LaunchDate = satcat.FormatDate(rec.LaunchDate)
+			entry.DecayDate = satcat.FormatDate(rec.DecayDate)
+		}
+		satellites = append(satellites, entry)
 	}
 	sort.Slice(satellites, func(i, j int) bool {
 		return satellites[i].Name < satellites[j].Name
@@ -67,6 +130,23 @@ func listSatellitesHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(satellites)
 }
 
+// fetchErrorStatus maps an error from the celestrak package to the status
+// returned to API clients.
+func fetchErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, celestrak.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, celestrak.ErrRateLimited), errors.Is(err, celestrak.ErrNotCached):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, celestrak.ErrServer), errors.Is(err, celestrak.ErrMalformed):
+		return http.StatusBadGateway
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // locationHandler provides the location of a satellite
 func locationHandler(w http.ResponseWriter, r *http.Request) {
 	noradID := r.URL.Query().Get("norad_id")
@@ -75,10 +155,17 @@ func locationHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Missing NORAD ID", http.StatusBadRequest)
 		return
 	}
+	catalogNumber, err := tle.ParseCatalogNumber(noradID)
+	if err != nil {
+		http.Error(w, "Invalid NORAD ID", http.StatusBadRequest)
+		return
+	}
+	noradID = strconv.Itoa(catalogNumber)
 
-	tleData, err := celestrak.GetSatelliteTLEByNoradID(noradID)
+	tleData, err := celestrak.NewClient().GetSatelliteTLEByNoradID(r.Context(), noradID)
 	if err != nil {
-		http.Error(w, "Unable to fetch TLE data", http.StatusInternalServerError)
+		logger.Error("Unable to fetch TLE data", "norad_id", noradID, "error", err)
+		http.Error(w, "Unable to fetch TLE data", fetchErrorStatus(err))
 		return
 	}
 
@@ -121,6 +208,11 @@ func locationHandler(w http.ResponseWriter, r *http.Request) {
 			"line2": tleData.Line2.LineString,
 		},
 	}
+	if params, err := tleData.OrbitParameters(); err == nil {
+		response["orbit"] = params
+	} else {
+		logger.Warn("[locationHandler] Unable to derive orbit parameters", "norad_id", noradID, "error", err)
+	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
@@ -0,0 +1,172 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// elementSetExtensions are the file extensions OpenDir reads.
+var elementSetExtensions = map[string]bool{
+	".tle": true, ".txt": true, ".2le": true, ".3le": true,
+	".json": true, ".xml": true, ".kvn": true, ".csv": true,
+}
+
+// Local serves element sets loaded into memory from files or a reader. Each
+// file is a group named after the file without its extension.
+type Local struct {
+	groups []localGroup
+}
+
+type localGroup struct {
+	name string
+	tles []tle.TLE
+}
+
+// OpenFile loads a TLE or OMM file, its format taken from the extension.
+func OpenFile(path string) (*Local, error) {
+	l := &Local{}
+	if err := l.addFile(path); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// OpenDir loads every TLE and OMM file directly inside dir. Cache metadata
+// written by the celestrak package is skipped, so a download directory can
+// be used as a source.
+func OpenDir(dir string) (*Local, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	l := &Local{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".meta.json") ||
+			!elementSetExtensions[strings.ToLower(filepath.Ext(name))] {
+			continue
+		}
+		if err := l.addFile(filepath.Join(dir, name)); err != nil {
+			return nil, err
+		}
+	}
+	if len(l.groups) == 0 {
+		return nil, fmt.Errorf("%s: no TLE or OMM files", dir)
+	}
+	return l, nil
+}
+
+// ReadLocal loads element sets from r, detecting their format, as a single
+// group called name.
+func ReadLocal(r io.Reader, name string) (*Local, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tles, err := tle.ReadFormat(bytes.NewReader(data), tle.DetectFormat(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &Local{groups: []localGroup{{name: name, tles: tles}}}, nil
+}
+
+func (l *Local) addFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	tles, err := tle.ReadFormat(file, tle.FormatOf(path))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	l.groups = append(l.groups, localGroup{name: name, tles: tles})
+	return nil
+}
+
+// GetSatelliteTLEByNoradID returns the element set of the satellite with the
+// latest epoch, wherever it was loaded from.
+func (l *Local) GetSatelliteTLEByNoradID(ctx context.Context, noradID string) (tle.TLE, error) {
+	catalogNumber, err := tle.ParseCatalogNumber(noradID)
+	if err != nil {
+		return tle.TLE{}, err
+	}
+	var latest tle.TLE
+	found := false
+	for _, group := range l.groups {
+		for _, t := range group.tles {
+			if t.Elements.CatalogNumber == catalogNumber && (!found || t.Elements.Epoch.After(latest.Elements.Epoch)) {
+				latest, found = t, true
+			}
+		}
+	}
+	if !found {
+		return tle.TLE{}, fmt.Errorf("NORAD ID %s: %w", strconv.Itoa(catalogNumber), ErrNotFound)
+	}
+	return latest, nil
+}
+
+func (l *Local) GetGroupTLEs(ctx context.Context, group string) ([]tle.TLE, error) {
+	for _, g := range l.groups {
+		if strings.EqualFold(g.name, group) {
+			return g.tles, nil
+		}
+	}
+	return nil, fmt.Errorf("group %q: %w", group, ErrNotFound)
+}
+
+// SearchByName returns the latest element set of every satellite whose name
+// contains name, ignoring case.
+func (l *Local) SearchByName(ctx context.Context, name string) ([]tle.TLE, error) {
+	entries, err := l.Index(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest := make([]tle.TLE, 0, len(entries))
+	for _, entry := range entries {
+		latest = append(latest, entry.TLE)
+	}
+	return filterByName(latest, name), nil
+}
+
+// Index lists every satellite once, with its latest element set and the
+// files it appears in.
+func (l *Local) Index(ctx context.Context) ([]IndexEntry, error) {
+	var entries []IndexEntry
+	index := map[int]int{}
+	for _, group := range l.groups {
+		for _, t := range group.tles {
+			i, seen := index[t.Elements.CatalogNumber]
+			if !seen {
+				index[t.Elements.CatalogNumber] = len(entries)
+				entries = append(entries, IndexEntry{TLE: t, Groups: []string{group.name}})
+				continue
+			}
+			if t.Elements.Epoch.After(entries[i].TLE.Elements.Epoch) {
+				entries[i].TLE = t
+			}
+			if !slices.Contains(entries[i].Groups, group.name) {
+				entries[i].Groups = append(entries[i].Groups, group.name)
+			}
+		}
+	}
+	return entries, nil
+}
+
+func (l *Local) Groups(ctx context.Context) ([]string, error) {
+	names := make([]string, 0, len(l.groups))
+	for _, group := range l.groups {
+		names = append(names, group.name)
+	}
+	return names, nil
+}
@@ -0,0 +1,102 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
+	"github.com/Mohammed-Ashour/tlego/pkg/spacetrack"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// CelesTrak serves element sets from CelesTrak, with groups taken from the
+// celestrak groups configuration.
+type CelesTrak struct {
+	Client *celestrak.Client
+	Config celestrak.CelestrakConfig
+}
+
+// NewCelesTrak returns a CelesTrak source using celestrak.NewClient and
+// celestrak.ReadCelestrakConfig.
+func NewCelesTrak() (*CelesTrak, error) {
+	config, err := celestrak.ReadCelestrakConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Celestrak configuration: %w", err)
+	}
+	return &CelesTrak{Client: celestrak.NewClient(), Config: config}, nil
+}
+
+func (s *CelesTrak) GetSatelliteTLEByNoradID(ctx context.Context, noradID string) (tle.TLE, error) {
+	return s.Client.GetSatelliteTLEByNoradID(ctx, noradID)
+}
+
+// GetSatelliteTLEsByNoradIDs fetches several satellites in batched queries.
+func (s *CelesTrak) GetSatelliteTLEsByNoradIDs(ctx context.Context, noradIDs []string) ([]tle.TLE, error) {
+	return s.Client.GetSatelliteTLEsByNoradIDs(ctx, noradIDs)
+}
+
+func (s *CelesTrak) GetGroupTLEs(ctx context.Context, group string) ([]tle.TLE, error) {
+	return s.Client.GetSatelliteGroupTLEs(ctx, group, s.Config)
+}
+
+// SearchByName searches the catalog index of the configured groups, which is
+// rebuilt once it is older than the client's CacheMaxAge.
+func (s *CelesTrak) SearchByName(ctx context.Context, name string) ([]tle.TLE, error) {
+	catalog, err := s.Client.Catalog(ctx, s.Config)
+	if err != nil {
+		return nil, err
+	}
+	var matches []tle.TLE
+	for _, entry := range catalog.SearchByName(name) {
+		matches = append(matches, entry.TLE)
+	}
+	return matches, nil
+}
+
+// Index lists the satellites of the catalog index of the configured groups.
+func (s *CelesTrak) Index(ctx context.Context) ([]IndexEntry, error) {
+	catalog, err := s.Client.Catalog(ctx, s.Config)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]IndexEntry, 0, len(catalog.Satellites))
+	for _, entry := range catalog.Satellites {
+		entries = append(entries, IndexEntry{TLE: entry.TLE, Groups: entry.Groups})
+	}
+	return entries, nil
+}
+
+func (s *CelesTrak) Groups(ctx context.Context) ([]string, error) {
+	names := make([]string, 0, len(s.Config.SatelliteGroups))
+	for _, group := range s.Config.SatelliteGroups {
+		names = append(names, group.Name)
+	}
+	return names, nil
+}
+
+// SpaceTrack serves element sets from Space-Track.org, which has no groups.
+type SpaceTrack struct {
+	*spacetrack.Client
+}
+
+func (s *SpaceTrack) GetGroupTLEs(ctx context.Context, group string) ([]tle.TLE, error) {
+	return nil, fmt.Errorf("Space-Track groups: %w", errors.ErrUnsupported)
+}
+
+func (s *SpaceTrack) SearchByName(ctx context.Context, name string) ([]tle.TLE, error) {
+	return s.Client.GetSatelliteTLEsByName(ctx, name)
+}
+
+// filterByName returns the element sets whose name contains name, ignoring case.
+func filterByName(tles []tle.TLE, name string) []tle.TLE {
+	name = strings.ToLower(name)
+	var matches []tle.TLE
+	for _, t := range tles {
+		if strings.Contains(strings.ToLower(t.Name), name) {
+			matches = append(matches, t)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,131 @@
+// Package source defines where tlego gets its element sets from, so commands
+// do not depend on a particular provider.
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/celestrak"
+	"github.com/Mohammed-Ashour/tlego/pkg/spacetrack"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+// ErrNotFound is returned by local sources that hold no matching element set.
+var ErrNotFound = errors.New("no matching element set")
+
+// Source provides element sets by NORAD ID, by group and by name.
+type Source interface {
+	// GetSatelliteTLEByNoradID returns the latest element set of a satellite.
+	GetSatelliteTLEByNoradID(ctx context.Context, noradID string) (tle.TLE, error)
+	// GetGroupTLEs returns every element set of a named group.
+	GetGroupTLEs(ctx context.Context, group string) ([]tle.TLE, error)
+	// SearchByName returns the element sets of every satellite whose name
+	// contains name, ignoring case.
+	SearchByName(ctx context.Context, name string) ([]tle.TLE, error)
+}
+
+// GroupLister is implemented by sources that can enumerate their groups.
+type GroupLister interface {
+	Groups(ctx context.Context) ([]string, error)
+}
+
+// Indexer is implemented by sources that can list every satellite they hold,
+// each once with its latest element set and the groups it belongs to.
+type Indexer interface {
+	Index(ctx context.Context) ([]IndexEntry, error)
+}
+
+// IndexEntry is one satellite listed by an Indexer.
+type IndexEntry struct {
+	TLE    tle.TLE
+	Groups []string
+}
+
+// BatchSource is implemented by sources that can look up several satellites
+// at once, more cheaply than one by one.
+type BatchSource interface {
+	GetSatelliteTLEsByNoradIDs(ctx context.Context, noradIDs []string) ([]tle.TLE, error)
+}
+
+// HistorySource also provides the element sets a satellite had in the past.
+type HistorySource interface {
+	Source
+	GetTLEHistory(ctx context.Context, noradID string, from, to time.Time) ([]tle.TLE, error)
+}
+
+var (
+	_ GroupLister   = (*CelesTrak)(nil)
+	_ Indexer       = (*CelesTrak)(nil)
+	_ BatchSource   = (*CelesTrak)(nil)
+	_ HistorySource = (*SpaceTrack)(nil)
+	_ GroupLister   = (*Local)(nil)
+	_ Indexer       = (*Local)(nil)
+)
+
+// GetSatelliteTLEsByNoradIDs looks up several satellites in src, in the order
+// given, through src's own batch lookup if it is a BatchSource. IDs src has
+// no element set for are skipped and reported together in an error wrapping
+// ErrNotFound, returned with the element sets that were found; any other
+// failure stops the lookup.
+func GetSatelliteTLEsByNoradIDs(ctx context.Context, src Source, noradIDs []string) ([]tle.TLE, error) {
+	if batch, ok := src.(BatchSource); ok {
+		return batch.GetSatelliteTLEsByNoradIDs(ctx, noradIDs)
+	}
+	tles := make([]tle.TLE, 0, len(noradIDs))
+	var missing []string
+	for _, id := range noradIDs {
+		t, err := src.GetSatelliteTLEByNoradID(ctx, id)
+		if IsNotFound(err) {
+			missing = append(missing, id)
+			continue
+		}
+		if err != nil {
+			return []tle.TLE{}, err
+		}
+		tles = append(tles, t)
+	}
+	if len(missing) > 0 {
+		return tles, fmt.Errorf("NORAD IDs %s: %w", strings.Join(missing, ", "), ErrNotFound)
+	}
+	return tles, nil
+}
+
+// IsNotFound reports whether err means a source had no matching element set.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, celestrak.ErrNotFound) || errors.Is(err, spacetrack.ErrNotFound)
+}
+
+// Open returns the source named by spec:
+//
+//	celestrak   CelesTrak (also the empty string)
+//	spacetrack  Space-Track.org, see spacetrack.LoadCredentials
+//	-           TLE or OMM data read from standard input
+//	<path>      a TLE or OMM file, or a directory of them
+func Open(spec string) (Source, error) {
+	switch strings.ToLower(spec) {
+	case "", "celestrak":
+		return NewCelesTrak()
+	case "spacetrack", "space-track":
+		client, err := spacetrack.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		return &SpaceTrack{Client: client}, nil
+	case "-":
+		return ReadLocal(os.Stdin, "stdin")
+	}
+
+	info, err := os.Stat(spec)
+	if err != nil {
+		return nil, fmt.Errorf("unknown source %q: not celestrak, spacetrack, - or a file", spec)
+	}
+	if info.IsDir() {
+		return OpenDir(spec)
+	}
+	return OpenFile(spec)
+}
@@ -0,0 +1,108 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+)
+
+const stations = `ISS (ZARYA)
+1 25544U 98067A   24057.91666667  .00016717  00000-0  10270-3 0  9005
+2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537
+CSS (TIANHE)
+1 48274U 21035A   24057.50000000  .00020000  00000-0  22000-3 0  9990
+2 48274  41.4700 100.0000 0005000  90.0000 270.0000 15.60000000150000
+`
+
+const laterISS = `ISS (ZARYA)
+1 25544U 98067A   24060.50000000  .00016717  00000-0  10270-3 0  9005
+2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537
+`
+
+func TestOpenDir(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "stations.tle"), []byte(stations), 0o644)
+	os.WriteFile(filepath.Join(dir, "iss.txt"), []byte(laterISS), 0o644)
+	os.WriteFile(filepath.Join(dir, "stations.tle.meta.json"), []byte(`{"url":"x"}`), 0o644)
+	os.WriteFile(filepath.Join(dir, "notes.md"), []byte("not elements"), 0o644)
+	// indexes the celestrak package keeps in the download directory
+	os.Mkdir(filepath.Join(dir, "index"), 0o755)
+	os.WriteFile(filepath.Join(dir, "index", "catalog.json"), []byte(`{"satellites":[]}`), 0o644)
+	os.WriteFile(filepath.Join(dir, "index", "satcat.csv"), []byte("OBJECT_NAME,OBJECT_ID\n"), 0o644)
+
+	src, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open(dir) error = %v", err)
+	}
+	ctx := context.Background()
+
+	groups, _ := src.(GroupLister).Groups(ctx)
+	if strings.Join(groups, ",") != "iss,stations" {
+		t.Errorf("Groups() = %v", groups)
+	}
+
+	iss, err := src.GetSatelliteTLEByNoradID(ctx, "25544")
+	if err != nil || iss.Elements.Epoch.YearDay() != 60 {
+		t.Errorf("expected the latest ISS element set, got day %d, error %v", iss.Elements.Epoch.YearDay(), err)
+	}
+	if _, err := src.GetSatelliteTLEByNoradID(ctx, "99999"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	tles, err := src.GetGroupTLEs(ctx, "Stations")
+	if err != nil || len(tles) != 2 {
+		t.Errorf("GetGroupTLEs() = %d TLEs, %v", len(tles), err)
+	}
+
+	matches, err := src.SearchByName(ctx, "zarya")
+	if err != nil || len(matches) != 1 || matches[0].Elements.Epoch.YearDay() != 60 {
+		t.Errorf("SearchByName() = %+v, %v", matches, err)
+	}
+}
+
+// batchLocal is a Local that counts its batch lookups.
+type batchLocal struct {
+	*Local
+	batches int
+}
+
+func (b *batchLocal) GetSatelliteTLEsByNoradIDs(ctx context.Context, noradIDs []string) ([]tle.TLE, error) {
+	b.batches++
+	return nil, nil
+}
+
+func TestGetSatelliteTLEsByNoradIDs(t *testing.T) {
+	local, err := ReadLocal(strings.NewReader(stations), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	tles, err := GetSatelliteTLEsByNoradIDs(ctx, local, []string{"48274", "99999", "25544"})
+	if !errors.Is(err, ErrNotFound) || len(tles) != 2 || tles[0].NoradID != "48274" || !strings.Contains(err.Error(), "99999") {
+		t.Errorf("GetSatelliteTLEsByNoradIDs() = %d TLEs, %v", len(tles), err)
+	}
+
+	batch := &batchLocal{Local: local}
+	if _, err := GetSatelliteTLEsByNoradIDs(ctx, batch, []string{"25544", "48274"}); err != nil || batch.batches != 1 {
+		t.Errorf("expected one batch lookup, got %d, %v", batch.batches, err)
+	}
+}
+
+func TestReadLocal(t *testing.T) {
+	src, err := ReadLocal(strings.NewReader(stations), "stdin")
+	if err != nil {
+		t.Fatalf("ReadLocal() error = %v", err)
+	}
+	if tles, err := src.GetGroupTLEs(context.Background(), "stdin"); err != nil || len(tles) != 2 {
+		t.Errorf("GetGroupTLEs() = %d TLEs, %v", len(tles), err)
+	}
+
+	if _, err := Open(filepath.Join(t.TempDir(), "missing.tle")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,289 @@
+// Package spacetrack fetches element sets from Space-Track.org, including the
+// historical element sets CelesTrak does not serve. Space-Track requires an
+// account; see LoadCredentials.
+package spacetrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/archive"
+	"github.com/Mohammed-Ashour/tlego/pkg/logger"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultBaseURL is the Space-Track server queried when Client.BaseURL is empty.
+const DefaultBaseURL = "https://www.space-track.org"
+
+const (
+	loginPath = "/ajaxauth/login"
+	queryPath = "/basicspacedata/query"
+)
+
+// DefaultMinInterval spaces out queries to stay within Space-Track's limit of
+// 30 requests per minute.
+const DefaultMinInterval = 2 * time.Second
+
+// Environment variables holding the Space-Track credentials. They take
+// precedence over the credentials file.
+const (
+	IdentityEnv = "SPACETRACK_IDENTITY"
+	PasswordEnv = "SPACETRACK_PASSWORD"
+)
+
+var (
+	ErrNoCredentials = errors.New("no Space-Track credentials")
+	ErrLoginFailed   = errors.New("Space-Track login failed")
+	ErrNotFound      = errors.New("no element sets found on Space-Track")
+)
+
+// Credentials of a Space-Track account.
+type Credentials struct {
+	Identity string `yaml:"identity"`
+	Password string `yaml:"password"`
+}
+
+// Client queries Space-Track. It logs in on the first query and again when
+// the session expires.
+type Client struct {
+	HTTPClient  *http.Client // must have a cookie jar to keep the session
+	BaseURL     string
+	Credentials Credentials
+	MinInterval time.Duration    // minimum delay between two requests
+	Archive     *archive.Archive // records every fetched element set; nil to disable
+
+	mu          sync.Mutex
+	loggedIn    bool
+	lastRequest time.Time
+}
+
+// NewClient returns a client using the credentials from LoadCredentials and
+// archiving to the default archive, if archive.ARCHIVE_DIR is set.
+func NewClient() (*Client, error) {
+	creds, err := LoadCredentials()
+	if err != nil {
+		return nil, err
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		HTTPClient:  &http.Client{Jar: jar, Timeout: 60 * time.Second},
+		BaseURL:     DefaultBaseURL,
+		Credentials: creds,
+		MinInterval: DefaultMinInterval,
+		Archive:     archive.Default(),
+	}, nil
+}
+
+// LoadCredentials reads the credentials from SPACETRACK_IDENTITY and
+// SPACETRACK_PASSWORD, falling back to spacetrack.yaml in the tlego directory
+// of the user config dir ($XDG_CONFIG_HOME/tlego on Linux).
+func LoadCredentials() (Credentials, error) {
+	creds := Credentials{
+		Identity: os.Getenv(IdentityEnv),
+		Password: os.Getenv(PasswordEnv),
+	}
+	if creds.Identity != "" && creds.Password != "" {
+		return creds, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return Credentials{}, ErrNoCredentials
+	}
+	fp := filepath.Join(dir, "tlego", "spacetrack.yaml")
+	data, err := os.ReadFile(fp)
+	if errors.Is(err, os.ErrNotExist) {
+		return Credentials{}, fmt.Errorf("%w: set %s and %s or write %s", ErrNoCredentials, IdentityEnv, PasswordEnv, fp)
+	}
+	if err != nil {
+		return Credentials{}, err
+	}
+	if err := yaml.Unmarshal(data, &creds); err != nil {
+		return Credentials{}, fmt.Errorf("%s: %w", fp, err)
+	}
+	if creds.Identity == "" || creds.Password == "" {
+		return Credentials{}, fmt.Errorf("%w in %s", ErrNoCredentials, fp)
+	}
+	return creds, nil
+}
+
+// GetSatelliteTLEByNoradID fetches the current element set of one satellite
+// from the gp class.
+func (c *Client) GetSatelliteTLEByNoradID(ctx context.Context, noradID string) (tle.TLE, error) {
+	tles, err := c.Query(ctx, "gp", "NORAD_CAT_ID", noradID)
+	if err != nil {
+		return tle.TLE{}, err
+	}
+	if len(tles) == 0 {
+		return tle.TLE{}, fmt.Errorf("NORAD ID %s: %w", noradID, ErrNotFound)
+	}
+	return tles[0], nil
+}
+
+// GetSatelliteTLEsByName fetches the current element sets of every satellite
+// whose name contains name.
+func (c *Client) GetSatelliteTLEsByName(ctx context.Context, name string) ([]tle.TLE, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return []tle.TLE{}, errors.New("empty satellite name")
+	}
+	return c.Query(ctx, "gp", "OBJECT_NAME", "~~"+name, "orderby", "NORAD_CAT_ID asc")
+}
+
+// GetTLEHistory fetches every element set of a satellite with an epoch
+// between from and to from the gp_history class, oldest first.
+func (c *Client) GetTLEHistory(ctx context.Context, noradID string, from, to time.Time) ([]tle.TLE, error) {
+	epochs := from.UTC().Format(time.DateOnly) + "--" + to.UTC().Add(24*time.Hour).Format(time.DateOnly)
+	tles, err := c.Query(ctx, "gp_history", "NORAD_CAT_ID", noradID, "EPOCH", epochs, "orderby", "EPOCH asc")
+	if err != nil {
+		return []tle.TLE{}, err
+	}
+
+	// Space-Track matches whole days, so trim to the requested span
+	matching := tles[:0]
+	for _, t := range tles {
+		if !t.Elements.Epoch.Before(from) && !t.Elements.Epoch.After(to) {
+			matching = append(matching, t)
+		}
+	}
+	if len(matching) == 0 {
+		return []tle.TLE{}, fmt.Errorf("NORAD ID %s between %s and %s: %w", noradID, from.Format(time.RFC3339), to.Format(time.RFC3339), ErrNotFound)
+	}
+	return matching, nil
+}
+
+// Query runs a query of a Space-Track request class, given as predicate and
+// value pairs, and decodes the element sets it returns. For example
+// Query(ctx, "gp", "OBJECT_NAME", "~~STARLINK") matches names containing
+// STARLINK.
+func (c *Client) Query(ctx context.Context, class string, predicates ...string) ([]tle.TLE, error) {
+	if len(predicates)%2 != 0 {
+		return []tle.TLE{}, fmt.Errorf("predicate %q has no value", predicates[len(predicates)-1])
+	}
+	path := queryPath + "/class/" + neturl.PathEscape(class)
+	for _, p := range predicates {
+		path += "/" + neturl.PathEscape(p)
+	}
+	path += "/format/3le"
+
+	body, err := c.get(ctx, path)
+	if err != nil {
+		return []tle.TLE{}, err
+	}
+	tles, err := tle.ReadTLEs(strings.NewReader(body))
+	if err != nil {
+		return []tle.TLE{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if c.Archive != nil {
+		if _, err := c.Archive.Add(tles); err != nil {
+			logger.Warn("Failed to archive TLEs", "dir", c.Archive.Dir, "error", err)
+		}
+	}
+	return tles, nil
+}
+
+// get fetches a query path, logging in first if needed and once more if the
+// session has expired.
+func (c *Client) get(ctx context.Context, path string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if !c.loggedIn {
+			if err := c.login(ctx); err != nil {
+				return "", err
+			}
+		}
+
+		resp, err := c.do(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return "", err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return "", err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			logger.Info("Space-Track session expired, logging in again")
+			c.loggedIn = false
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("%s: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+		}
+		return string(body), nil
+	}
+}
+
+// login posts the credentials; the session cookie ends up in the HTTP client's jar.
+func (c *Client) login(ctx context.Context) error {
+	if c.Credentials.Identity == "" || c.Credentials.Password == "" {
+		return ErrNoCredentials
+	}
+	form := neturl.Values{
+		"identity": {c.Credentials.Identity},
+		"password": {c.Credentials.Password},
+	}
+	resp, err := c.do(ctx, http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Space-Track answers a failed login with 200 and {"Login":"Failed"}
+	if resp.StatusCode != http.StatusOK || strings.Contains(string(body), `"Failed"`) {
+		return fmt.Errorf("%w: %s %s", ErrLoginFailed, resp.Status, strings.TrimSpace(string(body)))
+	}
+	c.loggedIn = true
+	return nil
+}
+
+// do sends a request once MinInterval has passed since the previous one.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	if wait := time.Until(c.lastRequest.Add(c.MinInterval)); wait > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	c.lastRequest = time.Now()
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(req)
+}
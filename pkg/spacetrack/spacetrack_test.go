@@ -0,0 +1,161 @@
+package spacetrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testHistory = `0 ISS (ZARYA)
+1 25544U 98067A   24001.50000000  .00016717  00000-0  10270-3 0  9005
+2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537
+0 ISS (ZARYA)
+1 25544U 98067A   24002.50000000  .00016717  00000-0  10270-3 0  9006
+2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563548
+`
+
+// standIn mimics the parts of Space-Track the client uses: a login form
+// setting a session cookie, and queries that require it.
+type standIn struct {
+	logins        int
+	paths         []string
+	expireSession bool
+}
+
+func (s *standIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == loginPath:
+		s.logins++
+		if r.Method != http.MethodPost || r.FormValue("identity") != "user" || r.FormValue("password") != "secret" {
+			w.Write([]byte(`{"Login":"Failed"}`))
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "chocolatechip", Value: "session", Path: "/"})
+		w.Write([]byte(`""`))
+	case strings.HasPrefix(r.URL.Path, queryPath):
+		s.paths = append(s.paths, r.URL.Path)
+		if _, err := r.Cookie("chocolatechip"); err != nil || s.expireSession {
+			s.expireSession = false
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if strings.Contains(r.URL.Path, "/99999/") {
+			return
+		}
+		w.Write([]byte(testHistory))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestClient(t *testing.T, url string) *Client {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{
+		HTTPClient:  &http.Client{Jar: jar},
+		BaseURL:     url,
+		Credentials: Credentials{Identity: "user", Password: "secret"},
+	}
+}
+
+func TestClient(t *testing.T) {
+	stub := &standIn{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	ctx := context.Background()
+
+	tle, err := client.GetSatelliteTLEByNoradID(ctx, "25544")
+	if err != nil {
+		t.Fatalf("GetSatelliteTLEByNoradID() error = %v", err)
+	}
+	if tle.Name != "ISS (ZARYA)" || stub.logins != 1 {
+		t.Errorf("got %q after %d logins", tle.Name, stub.logins)
+	}
+	if want := "/basicspacedata/query/class/gp/NORAD_CAT_ID/25544/format/3le"; stub.paths[0] != want {
+		t.Errorf("query path = %q, want %q", stub.paths[0], want)
+	}
+
+	from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	history, err := client.GetTLEHistory(ctx, "25544", from, from.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetTLEHistory() error = %v", err)
+	}
+	if len(history) != 1 || history[0].Elements.Epoch.YearDay() != 2 {
+		t.Errorf("expected the element set of Jan 2, got %+v", history)
+	}
+	if want := "/basicspacedata/query/class/gp_history/NORAD_CAT_ID/25544/EPOCH/2024-01-02--2024-01-04/orderby/EPOCH asc/format/3le"; stub.paths[1] != want {
+		t.Errorf("query path = %q, want %q", stub.paths[1], want)
+	}
+
+	// an expired session is renewed once
+	stub.expireSession = true
+	if _, err := client.GetSatelliteTLEByNoradID(ctx, "25544"); err != nil || stub.logins != 2 {
+		t.Errorf("expected a second login, got %d logins, error %v", stub.logins, err)
+	}
+
+	if _, err := client.GetSatelliteTLEByNoradID(ctx, "99999"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClientLoginFailed(t *testing.T) {
+	server := httptest.NewServer(&standIn{})
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	client.Credentials.Password = "wrong"
+
+	if _, err := client.GetSatelliteTLEByNoradID(context.Background(), "25544"); !errors.Is(err, ErrLoginFailed) {
+		t.Errorf("expected ErrLoginFailed, got %v", err)
+	}
+}
+
+func TestClientThrottle(t *testing.T) {
+	server := httptest.NewServer(&standIn{})
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	client.MinInterval = 20 * time.Millisecond
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSatelliteTLEByNoradID(context.Background(), "25544"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// login and two queries: at least two intervals
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("three requests took %v, expected at least 40ms", elapsed)
+	}
+}
+
+func TestLoadCredentials(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv(IdentityEnv, "")
+	t.Setenv(PasswordEnv, "")
+
+	if _, err := LoadCredentials(); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("expected ErrNoCredentials, got %v", err)
+	}
+
+	os.MkdirAll(filepath.Join(dir, "tlego"), 0o755)
+	os.WriteFile(filepath.Join(dir, "tlego", "spacetrack.yaml"), []byte("identity: file-user\npassword: file-secret\n"), 0o600)
+	if creds, err := LoadCredentials(); err != nil || creds.Identity != "file-user" {
+		t.Errorf("LoadCredentials() = %+v, %v", creds, err)
+	}
+
+	t.Setenv(IdentityEnv, "env-user")
+	t.Setenv(PasswordEnv, "env-secret")
+	if creds, err := LoadCredentials(); err != nil || creds.Identity != "env-user" {
+		t.Errorf("LoadCredentials() = %+v, %v", creds, err)
+	}
+}
@@ -0,0 +1,112 @@
+// Package sun locates the Sun and the Earth's shadow, to tell whether a
+// satellite is sunlit and whether an observer's sky is dark.
+package sun
+
+import (
+	"math"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+)
+
+const (
+	au          = 149597870.7 // km
+	sunRadius   = 696000.0    // km
+	earthRadius = 6378.137    // km
+	deg         = math.Pi / 180
+)
+
+// Position returns the Sun's geocentric position at t in km, in the
+// equatorial frame of date that TEME approximates. It uses the Astronomical
+// Almanac's low-precision formulae, good to about 0.01° until 2050.
+func Position(t time.Time) observer.Vector {
+	tut1 := (observer.JulianDate(t) - 2451545.0) / 36525
+	meanLongitude := 280.460 + 36000.771*tut1
+	anomaly := (357.5291092 + 35999.05034*tut1) * deg
+	longitude := (meanLongitude + 1.914666471*math.Sin(anomaly) + 0.019994643*math.Sin(2*anomaly)) * deg
+	distance := (1.000140612 - 0.016708617*math.Cos(anomaly) - 0.000139589*math.Cos(2*anomaly)) * au
+	obliquity := (23.439291 - 0.0130042*tut1) * deg
+
+	sinLon, cosLon := math.Sincos(longitude)
+	return observer.Vector{
+		distance * cosLon,
+		distance * math.Cos(obliquity) * sinLon,
+		distance * math.Sin(obliquity) * sinLon,
+	}
+}
+
+// Elevation returns the Sun's elevation in degrees above o's horizon at t.
+func Elevation(o observer.Observer, t time.Time) float64 {
+	return o.Look(observer.State{Position: Position(t)}, t).Elevation
+}
+
+// Shadow is how much of the Sun a satellite sees past the Earth.
+type Shadow int
+
+const (
+	Sunlit   Shadow = iota
+	Penumbra        // the Earth covers part of the Sun
+	Umbra           // the Earth covers all of the Sun
+)
+
+func (s Shadow) String() string {
+	switch s {
+	case Penumbra:
+		return "penumbra"
+	case Umbra:
+		return "umbra"
+	}
+	return "sunlit"
+}
+
+// ShadowOf returns the shadow a satellite at position sat is in with the Sun
+// at position sun, both geocentric in km, with a conical shadow model
+// comparing the apparent disks of the Sun and the Earth.
+func ShadowOf(sat, sun observer.Vector) Shadow {
+	toSun := sun.Sub(sat)
+	toEarth := observer.Vector{-sat[0], -sat[1], -sat[2]}
+	sunDisk := math.Asin(sunRadius / toSun.Norm())
+	earthDisk := math.Asin(math.Min(earthRadius/toEarth.Norm(), 1))
+	separation := math.Acos(math.Max(-1, math.Min(1, toSun.Dot(toEarth)/(toSun.Norm()*toEarth.Norm()))))
+
+	switch {
+	case separation >= earthDisk+sunDisk:
+		return Sunlit
+	case earthDisk > sunDisk && separation <= earthDisk-sunDisk:
+		return Umbra
+	}
+	return Penumbra
+}
+
+// ShadowAt propagates p to t and returns the shadow the satellite is in.
+func ShadowAt(p observer.Propagator, t time.Time) (Shadow, error) {
+	s, err := p.StateAt(t)
+	if err != nil {
+		return Sunlit, err
+	}
+	return ShadowOf(s.Position, Position(t)), nil
+}
+
+// PhaseAngle returns the angle in degrees at a satellite at position sat
+// between the Sun and observer o at t: 0° when o sees it fully lit from
+// behind, 180° when o looks at its dark side.
+func PhaseAngle(sat observer.Vector, o observer.Observer, t time.Time) float64 {
+	satECEF, _ := observer.TEMEToECEF(observer.State{Position: sat}, t)
+	sunECEF, _ := observer.TEMEToECEF(observer.State{Position: Position(t)}, t)
+	toSun, toObserver := sunECEF.Sub(satECEF), o.ECEF().Sub(satECEF)
+	cos := toSun.Dot(toObserver) / (toSun.Norm() * toObserver.Norm())
+	return math.Acos(math.Max(-1, math.Min(1, cos))) / deg
+}
+
+// Magnitude estimates the visual magnitude of a satellite with the given
+// standard magnitude (at 1000 km range and 90° phase) seen at rangeKm and
+// phase angle in degrees, treating it as a diffusely reflecting sphere.
+func Magnitude(standard, rangeKm, phase float64) float64 {
+	phi := phase * deg
+	// the sphere's brightness relative to half phase, where it is 1/π
+	fraction := math.Sin(phi) + (math.Pi-phi)*math.Cos(phi)
+	if fraction <= 0 {
+		return math.Inf(1)
+	}
+	return standard + 5*math.Log10(rangeKm/1000) - 2.5*math.Log10(fraction)
+}
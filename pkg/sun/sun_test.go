@@ -0,0 +1,74 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Mohammed-Ashour/tlego/pkg/observer"
+)
+
+func TestPosition(t *testing.T) {
+	// March equinox and June solstice of 2024
+	equinox := Position(time.Date(2024, 3, 20, 3, 6, 0, 0, time.UTC))
+	if d := equinox.Norm() / au; d < 0.99 || d > 1.0 {
+		t.Errorf("distance = %.4f AU", d)
+	}
+	if lon := math.Atan2(equinox[1], equinox[0]) / deg; math.Abs(lon) > 0.05 {
+		t.Errorf("right ascension at the equinox = %.3f°, want 0", lon)
+	}
+	solstice := Position(time.Date(2024, 6, 20, 20, 51, 0, 0, time.UTC))
+	if dec := math.Asin(solstice[2]/solstice.Norm()) / deg; math.Abs(dec-23.44) > 0.02 {
+		t.Errorf("declination at the solstice = %.3f°, want 23.44", dec)
+	}
+}
+
+func TestElevation(t *testing.T) {
+	noon := time.Date(2024, 3, 20, 12, 7, 0, 0, time.UTC) // apparent noon at Greenwich
+	if el := Elevation(observer.Observer{}, noon); el < 89 {
+		t.Errorf("noon elevation on the equator = %.2f°, want about 90", el)
+	}
+	if el := Elevation(observer.Observer{}, noon.Add(12*time.Hour)); el > -89 {
+		t.Errorf("midnight elevation on the equator = %.2f°, want about -90", el)
+	}
+}
+
+func TestShadowOf(t *testing.T) {
+	sun := observer.Vector{au, 0, 0}
+	if s := ShadowOf(observer.Vector{7000, 0, 0}, sun); s != Sunlit {
+		t.Errorf("day side: %v", s)
+	}
+	if s := ShadowOf(observer.Vector{-7000, 0, 0}, sun); s != Umbra {
+		t.Errorf("night side: %v", s)
+	}
+
+	// moving out of the shadow sideways goes umbra, penumbra, sunlit, with
+	// a penumbra a few tens of km wide at LEO
+	var last Shadow = Umbra
+	var penumbra float64
+	for y := 0.0; y < 8000; y++ {
+		s := ShadowOf(observer.Vector{-7000, y, 0}, sun)
+		if s > last {
+			t.Fatalf("shadow went back from %v to %v at %v km", last, s, y)
+		}
+		if s == Penumbra {
+			penumbra++
+		}
+		last = s
+	}
+	if last != Sunlit || penumbra < 5 || penumbra > 100 {
+		t.Errorf("ended %v after %v km of penumbra", last, penumbra)
+	}
+}
+
+func TestMagnitude(t *testing.T) {
+	if m := Magnitude(-1.8, 1000, 90); math.Abs(m+1.8) > 1e-9 {
+		t.Errorf("at the standard distance and phase = %v, want -1.8", m)
+	}
+	if m := Magnitude(2, 2000, 90); math.Abs(m-(2+5*math.Log10(2))) > 1e-9 {
+		t.Errorf("at twice the distance = %v", m)
+	}
+	if Magnitude(2, 1000, 30) >= 2 || Magnitude(2, 1000, 150) <= 2 {
+		t.Error("a fuller phase should be brighter")
+	}
+}
@@ -0,0 +1,64 @@
+package tle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// alpha5Letters maps the leading Alpha-5 letter to its value. I and O are
+// skipped to avoid confusion with 1 and 0, so A=10 ... Z=33.
+const alpha5Letters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// MaxTLECatalogNumber is the largest catalog number a TLE can carry (Z9999).
+const MaxTLECatalogNumber = 339999
+
+// ParseCatalogNumber decodes a NORAD catalog number. It accepts plain
+// numbers of up to 9 digits, as used by OMM, and the 5-character Alpha-5
+// form used by TLEs for numbers above 99999, e.g. "A0001" for 100001.
+func ParseCatalogNumber(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty catalog number")
+	}
+
+	if c := s[0]; (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+		if len(s) != 5 {
+			return 0, fmt.Errorf("invalid Alpha-5 catalog number %q", s)
+		}
+		idx := strings.IndexByte(alpha5Letters, strings.ToUpper(s[:1])[0])
+		if idx < 0 {
+			return 0, fmt.Errorf("invalid Alpha-5 letter in catalog number %q", s)
+		}
+		rest, err := strconv.Atoi(s[1:])
+		if err != nil || strings.ContainsAny(s[1:], "+-") {
+			return 0, fmt.Errorf("invalid Alpha-5 catalog number %q", s)
+		}
+		return (idx+10)*10000 + rest, nil
+	}
+
+	if len(s) > 9 {
+		return 0, fmt.Errorf("catalog number %q longer than 9 digits", s)
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("catalog number must be numeric or Alpha-5: %q", s)
+		}
+	}
+	return strconv.Atoi(s)
+}
+
+// FormatCatalogNumber encodes a catalog number into the 5 TLE columns,
+// switching to Alpha-5 above 99999.
+func FormatCatalogNumber(n int) (string, error) {
+	switch {
+	case n < 0:
+		return "", fmt.Errorf("negative catalog number %d", n)
+	case n <= 99999:
+		return fmt.Sprintf("%05d", n), nil
+	case n <= MaxTLECatalogNumber:
+		return fmt.Sprintf("%c%04d", alpha5Letters[n/10000-10], n%10000), nil
+	default:
+		return "", fmt.Errorf("catalog number %d cannot be represented in a TLE", n)
+	}
+}
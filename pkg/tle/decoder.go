@@ -0,0 +1,128 @@
+package tle
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Decoder reads TLE sets one at a time from an input stream.
+// It accepts 2-line and 3-line (named) sets, blank lines, CRLF line endings
+// and the "0 " prefix some catalogs put in front of the name line.
+//
+//	dec := tle.NewDecoder(resp.Body)
+//	for dec.Next() {
+//		t := dec.TLE()
+//		...
+//	}
+//	if err := dec.Err(); err != nil {
+//		...
+//	}
+type Decoder struct {
+	scanner *bufio.Scanner
+	mode    ParseMode
+	lineNo  int
+	current TLE
+	err     error
+}
+
+// NewDecoder returns a Lenient decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return NewDecoderMode(r, Lenient)
+}
+
+// NewDecoderMode returns a decoder reading from r that validates each set according to mode.
+func NewDecoderMode(r io.Reader, mode ParseMode) *Decoder {
+	return &Decoder{
+		scanner: bufio.NewScanner(r),
+		mode:    mode,
+	}
+}
+
+// Next advances to the next TLE set. It returns false at the end of the
+// input or on the first error, which is then available from Err.
+func (d *Decoder) Next() bool {
+	if d.err != nil {
+		return false
+	}
+
+	var name, line1 string
+	line1No := 0
+	for d.scanner.Scan() {
+		d.lineNo++
+		line := strings.TrimRight(d.scanner.Text(), " \t\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		switch {
+		case isElementLine(line, '1'):
+			if line1 != "" {
+				d.err = fmt.Errorf("line %d: line 1 follows line 1 at line %d without a line 2", d.lineNo, line1No)
+				return false
+			}
+			line1, line1No = line, d.lineNo
+		case isElementLine(line, '2'):
+			if line1 == "" {
+				d.err = fmt.Errorf("line %d: line 2 without a preceding line 1", d.lineNo)
+				return false
+			}
+			t, err := ParseTLEMode(line1, line, name, d.mode)
+			if err != nil {
+				d.err = fmt.Errorf("line %d: %w", line1No, err)
+				return false
+			}
+			d.current = t
+			return true
+		default:
+			if line1 != "" {
+				d.err = fmt.Errorf("line %d: expected line 2 after line 1 at line %d", d.lineNo, line1No)
+				return false
+			}
+			name = strings.TrimSpace(strings.TrimPrefix(line, "0 "))
+		}
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		d.err = err
+		return false
+	}
+	if line1 != "" {
+		d.err = fmt.Errorf("line %d: unexpected end of input after line 1", line1No)
+	}
+	return false
+}
+
+// TLE returns the set decoded by the most recent call to Next.
+func (d *Decoder) TLE() TLE {
+	return d.current
+}
+
+// Err returns the first error encountered by the decoder.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// ReadTLEs decodes every TLE set from r in Lenient mode.
+func ReadTLEs(r io.Reader) ([]TLE, error) {
+	return ReadTLEsMode(r, Lenient)
+}
+
+// ReadTLEsMode decodes every TLE set from r, validating each according to mode.
+func ReadTLEsMode(r io.Reader, mode ParseMode) ([]TLE, error) {
+	dec := NewDecoderMode(r, mode)
+	var tles []TLE
+	for dec.Next() {
+		tles = append(tles, dec.TLE())
+	}
+	if err := dec.Err(); err != nil {
+		return nil, err
+	}
+	return tles, nil
+}
+
+// isElementLine reports whether line looks like element line n of a TLE set.
+func isElementLine(line string, n byte) bool {
+	return len(line) >= 2 && line[0] == n && line[1] == ' '
+}
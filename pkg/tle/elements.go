@@ -0,0 +1,194 @@
+package tle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Elements holds the typed orbital elements decoded from a TLE.
+// Angles are in degrees and mean motion in revolutions per day.
+type Elements struct {
+	CatalogNumber     int
+	Classification    string
+	IntlDesignator    string
+	Epoch             time.Time
+	MeanMotionDot     float64 // first derivative of mean motion / 2, rev/day^2
+	MeanMotionDDot    float64 // second derivative of mean motion / 6, rev/day^3
+	Bstar             float64 // drag term, 1/earth radii
+	EphemerisType     int
+	ElementSetNumber  int
+	Inclination       float64
+	RightAscension    float64
+	Eccentricity      float64
+	ArgumentOfPerigee float64
+	MeanAnomaly       float64
+	MeanMotion        float64
+	RevolutionNumber  int
+}
+
+// ParseElements decodes the typed element set from the two raw TLE lines.
+func ParseElements(line1, line2 string) (Elements, error) {
+	if len(line1) < 69 {
+		return Elements{}, fmt.Errorf("line 1 too short: %d chars", len(line1))
+	}
+	if len(line2) < 69 {
+		return Elements{}, fmt.Errorf("line 2 too short: %d chars", len(line2))
+	}
+
+	var e Elements
+	var err error
+
+	if e.CatalogNumber, err = ParseCatalogNumber(line1[2:7]); err != nil {
+		return Elements{}, fmt.Errorf("line 1: %w", err)
+	}
+	e.Classification = strings.TrimSpace(line1[7:8])
+	e.IntlDesignator = strings.TrimSpace(line1[9:17])
+
+	epochYear, err := parseIntField(line1, 18, 20, "epoch year")
+	if err != nil {
+		return Elements{}, err
+	}
+	epochDay, err := parseFloatField(line1, 20, 32, "epoch day")
+	if err != nil {
+		return Elements{}, err
+	}
+	e.Epoch = epochTime(epochYear, epochDay)
+
+	if e.MeanMotionDot, err = parseFloatField(line1, 33, 43, "first derivative"); err != nil {
+		return Elements{}, err
+	}
+	if e.MeanMotionDDot, err = parseExponentField(line1, 44, 52, "second derivative"); err != nil {
+		return Elements{}, err
+	}
+	if e.Bstar, err = parseExponentField(line1, 53, 61, "bstar"); err != nil {
+		return Elements{}, err
+	}
+	if e.EphemerisType, err = parseIntField(line1, 62, 63, "ephemeris type"); err != nil {
+		return Elements{}, err
+	}
+	if e.ElementSetNumber, err = parseIntField(line1, 64, 68, "element set number"); err != nil {
+		return Elements{}, err
+	}
+
+	if e.Inclination, err = parseFloatField(line2, 8, 16, "inclination"); err != nil {
+		return Elements{}, err
+	}
+	if e.RightAscension, err = parseFloatField(line2, 17, 25, "right ascension"); err != nil {
+		return Elements{}, err
+	}
+	ecc := strings.TrimSpace(line2[26:33])
+	if e.Eccentricity, err = strconv.ParseFloat("0."+ecc, 64); err != nil {
+		return Elements{}, fmt.Errorf("eccentricity %q: %w", ecc, err)
+	}
+	if e.ArgumentOfPerigee, err = parseFloatField(line2, 34, 42, "argument of perigee"); err != nil {
+		return Elements{}, err
+	}
+	if e.MeanAnomaly, err = parseFloatField(line2, 43, 51, "mean anomaly"); err != nil {
+		return Elements{}, err
+	}
+	if e.MeanMotion, err = parseFloatField(line2, 52, 63, "mean motion"); err != nil {
+		return Elements{}, err
+	}
+	if e.RevolutionNumber, err = parseIntField(line2, 63, 68, "revolution number"); err != nil {
+		return Elements{}, err
+	}
+
+	return e, nil
+}
+
+// ObjectID returns the international designator in the COSPAR form used by
+// OMMs and SATCAT, e.g. 1998-067A for the TLE designator 98067A.
+func (e Elements) ObjectID() string {
+	return ommObjectID(strings.TrimSpace(e.IntlDesignator))
+}
+
+// LaunchYear returns the year encoded in the international designator, or 0
+// if it has none.
+func (e Elements) LaunchYear() int {
+	designator := strings.TrimSpace(e.IntlDesignator)
+	if len(designator) < 2 {
+		return 0
+	}
+	year, err := strconv.Atoi(designator[:2])
+	if err != nil {
+		return 0
+	}
+	return fullYear(year)
+}
+
+// fullYear expands a two-digit TLE year. Years 57-99 belong to the 1900s,
+// since no satellite predates Sputnik in 1957, and 00-56 to the 2000s.
+func fullYear(year int) int {
+	if year < 57 {
+		return 2000 + year
+	}
+	return 1900 + year
+}
+
+// epochTime converts a two-digit TLE epoch year and fractional day of year into UTC.
+func epochTime(epochYear int, epochDay float64) time.Time {
+	year := fullYear(epochYear)
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration((epochDay - 1) * float64(24*time.Hour))).Round(time.Microsecond)
+}
+
+// parseIntField parses the columns [start, end) of line as an integer.
+// Blank fields decode to zero.
+func parseIntField(line string, start, end int, name string) (int, error) {
+	value := strings.TrimSpace(line[start:end])
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q: %w", name, value, err)
+	}
+	return n, nil
+}
+
+// parseFloatField parses the columns [start, end) of line as a decimal number.
+// Blank fields decode to zero.
+func parseFloatField(line string, start, end int, name string) (float64, error) {
+	value := strings.TrimSpace(line[start:end])
+	if value == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q: %w", name, value, err)
+	}
+	return f, nil
+}
+
+// parseExponentField parses the columns [start, end) of line written in the
+// TLE implied-decimal exponent notation, e.g. " 12345-3" for 0.12345e-3.
+func parseExponentField(line string, start, end int, name string) (float64, error) {
+	value := strings.TrimSpace(line[start:end])
+	f, err := ParseExponent(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q: %w", name, value, err)
+	}
+	return f, nil
+}
+
+// ParseExponent decodes a value in the TLE implied-decimal exponent notation,
+// where "-11606-4" means -0.11606e-4. An empty string decodes to zero.
+func ParseExponent(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if len(value) < 3 {
+		return 0, fmt.Errorf("too short for exponent notation")
+	}
+
+	mantissa, exponent := value[:len(value)-2], value[len(value)-2:]
+	sign := ""
+	if mantissa[0] == '-' || mantissa[0] == '+' {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	mantissa = strings.TrimPrefix(mantissa, ".")
+	return strconv.ParseFloat(sign+"0."+mantissa+"e"+exponent, 64)
+}
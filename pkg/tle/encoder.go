@@ -0,0 +1,190 @@
+package tle
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Encode formats the element set as TLE line 1 and line 2, each ending in a
+// freshly computed checksum.
+func (e Elements) Encode() (line1, line2 string, err error) {
+	catalogNumber, err := FormatCatalogNumber(e.CatalogNumber)
+	if err != nil {
+		return "", "", err
+	}
+	if len(e.IntlDesignator) > 8 {
+		return "", "", fmt.Errorf("international designator %q longer than 8 chars", e.IntlDesignator)
+	}
+	if e.Eccentricity < 0 || e.Eccentricity >= 1 {
+		return "", "", fmt.Errorf("eccentricity %v out of range [0, 1)", e.Eccentricity)
+	}
+	if e.ElementSetNumber < 0 || e.ElementSetNumber > 9999 {
+		return "", "", fmt.Errorf("element set number %d out of range", e.ElementSetNumber)
+	}
+	if e.RevolutionNumber < 0 || e.RevolutionNumber > 99999 {
+		return "", "", fmt.Errorf("revolution number %d out of range", e.RevolutionNumber)
+	}
+	if e.EphemerisType < 0 || e.EphemerisType > 9 {
+		return "", "", fmt.Errorf("ephemeris type %d out of range", e.EphemerisType)
+	}
+
+	classification := e.Classification
+	if classification == "" {
+		classification = "U"
+	}
+	epochYear, epochDay := epochFields(e.Epoch)
+	firstDerivative, err := formatDerivative(e.MeanMotionDot)
+	if err != nil {
+		return "", "", fmt.Errorf("first derivative: %w", err)
+	}
+	secondDerivative, err := FormatExponent(e.MeanMotionDDot)
+	if err != nil {
+		return "", "", fmt.Errorf("second derivative: %w", err)
+	}
+	bstar, err := FormatExponent(e.Bstar)
+	if err != nil {
+		return "", "", fmt.Errorf("bstar: %w", err)
+	}
+
+	line1 = fmt.Sprintf("1 %s%1s %-8s %02d%012.8f %s %s %s %d %4d",
+		catalogNumber, classification, e.IntlDesignator,
+		epochYear, epochDay, firstDerivative, secondDerivative, bstar,
+		e.EphemerisType, e.ElementSetNumber)
+
+	ecc := int(math.Round(e.Eccentricity * 1e7))
+	if ecc > 9999999 {
+		ecc = 9999999
+	}
+	line2 = fmt.Sprintf("2 %s %8.4f %8.4f %07d %8.4f %8.4f %11.8f%5d",
+		catalogNumber, normalizeDegrees(e.Inclination), normalizeDegrees(e.RightAscension), ecc,
+		normalizeDegrees(e.ArgumentOfPerigee), normalizeDegrees(e.MeanAnomaly),
+		e.MeanMotion, e.RevolutionNumber)
+
+	if len(line1) != 68 {
+		return "", "", fmt.Errorf("encoded line 1 is %d chars, want 68 before checksum", len(line1))
+	}
+	if len(line2) != 68 {
+		return "", "", fmt.Errorf("encoded line 2 is %d chars, want 68 before checksum", len(line2))
+	}
+
+	line1 += strconv.Itoa(Checksum(line1))
+	line2 += strconv.Itoa(Checksum(line2))
+	return line1, line2, nil
+}
+
+// NewTLE encodes the element set and parses it back into a TLE, so the
+// line fields and line strings agree with the typed elements.
+func NewTLE(name string, e Elements) (TLE, error) {
+	line1, line2, err := e.Encode()
+	if err != nil {
+		return TLE{}, err
+	}
+	return ParseTLEMode(line1, line2, name, Strict)
+}
+
+// FormatExponent formats a value in the 8-column TLE implied-decimal
+// exponent notation, e.g. 0.12345e-3 becomes " 12345-3".
+// It is the inverse of ParseExponent.
+func FormatExponent(value float64) (string, error) {
+	if value == 0 {
+		return " 00000+0", nil
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "", fmt.Errorf("cannot encode %v", value)
+	}
+
+	sign := " "
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	exponent := int(math.Floor(math.Log10(value))) + 1
+	mantissa := int(math.Round(value / math.Pow(10, float64(exponent)) * 1e5))
+	if mantissa >= 100000 {
+		mantissa /= 10
+		exponent++
+	}
+	if mantissa == 0 {
+		return " 00000+0", nil
+	}
+	if exponent < -9 || exponent > 9 {
+		return "", fmt.Errorf("%v out of range for exponent notation", value)
+	}
+
+	exponentSign := "+"
+	if exponent < 0 {
+		exponentSign = "-"
+		exponent = -exponent
+	}
+	return fmt.Sprintf("%s%05d%s%d", sign, mantissa, exponentSign, exponent), nil
+}
+
+// formatDerivative formats the first derivative of mean motion as the
+// 10-column " .dddddddd" field.
+func formatDerivative(value float64) (string, error) {
+	sign := " "
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	digits := fmt.Sprintf("%.8f", value)
+	if !strings.HasPrefix(digits, "0.") {
+		return "", fmt.Errorf("%v out of range (-1, 1)", value)
+	}
+	return sign + digits[1:], nil
+}
+
+// normalizeDegrees wraps an angle into [0, 360) so it fits the 8-column fields.
+func normalizeDegrees(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	if math.Round(angle*1e4) >= 360*1e4 {
+		angle = 0
+	}
+	return angle
+}
+
+// epochFields splits an epoch into the two-digit year and fractional day of year.
+func epochFields(epoch time.Time) (int, float64) {
+	epoch = epoch.UTC()
+	start := time.Date(epoch.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := 1 + epoch.Sub(start).Hours()/24
+	return epoch.Year() % 100, day
+}
+
+// Encoder writes TLE sets to an output stream.
+type Encoder struct {
+	w *bufio.Writer
+}
+
+// NewEncoder returns an encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes t as a 3-line set, or a 2-line set when it has no name.
+// The element lines are generated from t.Elements, not copied from the
+// original line strings, so edited elements are reflected in the output.
+func (enc *Encoder) Encode(t TLE) error {
+	line1, line2, err := t.Elements.Encode()
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", t.Name, err)
+	}
+	if t.Name != "" {
+		if _, err := fmt.Fprintln(enc.w, t.Name); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(enc.w, "%s\n%s\n", line1, line2); err != nil {
+		return err
+	}
+	return enc.w.Flush()
+}
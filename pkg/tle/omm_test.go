@@ -0,0 +1,155 @@
+package tle
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const sampleOMMJSON = `[{
+    "OBJECT_NAME": "ISS (ZARYA)",
+    "OBJECT_ID": "1998-067A",
+    "EPOCH": "2024-02-26T21:54:52.995744",
+    "MEAN_MOTION": 15.49808581,
+    "ECCENTRICITY": 0.0005713,
+    "INCLINATION": 51.6403,
+    "RA_OF_ASC_NODE": 179.4367,
+    "ARG_OF_PERICENTER": 6.8573,
+    "MEAN_ANOMALY": 94.2478,
+    "EPHEMERIS_TYPE": 0,
+    "CLASSIFICATION_TYPE": "U",
+    "NORAD_CAT_ID": 25544,
+    "ELEMENT_SET_NO": 999,
+    "REV_AT_EPOCH": 441289,
+    "BSTAR": 0.00030362,
+    "MEAN_MOTION_DOT": 0.0001648,
+    "MEAN_MOTION_DDOT": 0
+}]`
+
+const sampleOMMKVN = `CCSDS_OMM_VERS      = 2.0
+COMMENT GENERATED VIA SPACE-TRACK.ORG API
+CREATION_DATE       = 2024-02-27T00:26:13
+ORIGINATOR          = 18 SPCS
+OBJECT_NAME         = ISS (ZARYA)
+OBJECT_ID           = 1998-067A
+CENTER_NAME         = EARTH
+REF_FRAME           = TEME
+TIME_SYSTEM         = UTC
+MEAN_ELEMENT_THEORY = SGP4
+EPOCH               = 2024-02-26T21:54:52.995744
+MEAN_MOTION         = 15.49808581 [rev/day]
+ECCENTRICITY        = .0005713
+INCLINATION         = 51.6403 [deg]
+RA_OF_ASC_NODE      = 179.4367 [deg]
+ARG_OF_PERICENTER   = 6.8573 [deg]
+MEAN_ANOMALY        = 94.2478 [deg]
+EPHEMERIS_TYPE      = 0
+CLASSIFICATION_TYPE = U
+NORAD_CAT_ID        = 25544
+ELEMENT_SET_NO      = 999
+REV_AT_EPOCH        = 44128
+BSTAR               = .30362E-3 [1/ER]
+MEAN_MOTION_DOT     = .1648E-3 [rev/day**2]
+MEAN_MOTION_DDOT    = 0 [rev/day**3]
+`
+
+func checkISSElements(t *testing.T, tles []TLE) {
+	t.Helper()
+	if len(tles) != 1 {
+		t.Fatalf("expected 1 element set, got %d", len(tles))
+	}
+	got := tles[0]
+	if got.Name != "ISS (ZARYA)" || got.NoradID != "25544" {
+		t.Errorf("Name/NoradID: got %q/%q", got.Name, got.NoradID)
+	}
+	e := got.Elements
+	if e.IntlDesignator != "98067A" {
+		t.Errorf("IntlDesignator: got %q, want 98067A", e.IntlDesignator)
+	}
+	if e.Epoch.Year() != 2024 || e.Epoch.YearDay() != 57 {
+		t.Errorf("Epoch: got %v", e.Epoch)
+	}
+	if math.Abs(e.MeanMotion-15.49808581) > 1e-12 || math.Abs(e.Bstar-0.00030362) > 1e-15 {
+		t.Errorf("MeanMotion/Bstar: got %v/%v", e.MeanMotion, e.Bstar)
+	}
+	if got.Line1.LineString == "" || len(Validate(got.Line1.LineString, got.Line2.LineString)) != 0 {
+		t.Errorf("expected valid TLE lines, got\n%s", got)
+	}
+}
+
+func TestReadOMMJSON(t *testing.T) {
+	tles, err := ReadOMMJSON(strings.NewReader(sampleOMMJSON))
+	if err != nil {
+		t.Fatalf("ReadOMMJSON failed: %v", err)
+	}
+	checkISSElements(t, tles)
+}
+
+func TestReadOMMKVN(t *testing.T) {
+	tles, err := ReadOMMKVN(strings.NewReader(sampleOMMKVN))
+	if err != nil {
+		t.Fatalf("ReadOMMKVN failed: %v", err)
+	}
+	checkISSElements(t, tles)
+}
+
+func TestOMMRoundTrip(t *testing.T) {
+	tles, err := ReadOMMJSON(strings.NewReader(sampleOMMJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []Format{FormatTLE, FormatXML, FormatKVN, FormatJSON, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf strings.Builder
+			if err := WriteFormat(&buf, format, tles); err != nil {
+				t.Fatalf("WriteFormat failed: %v", err)
+			}
+			got, err := ReadFormat(strings.NewReader(buf.String()), format)
+			if err != nil {
+				t.Fatalf("ReadFormat failed: %v\n%s", err, buf.String())
+			}
+			checkISSElements(t, got)
+		})
+	}
+}
+
+func TestOMMNineDigitCatalogNumber(t *testing.T) {
+	input := strings.Replace(sampleOMMJSON, `"NORAD_CAT_ID": 25544`, `"NORAD_CAT_ID": 270000123`, 1)
+	tles, err := ReadOMMJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadOMMJSON failed: %v", err)
+	}
+	if tles[0].NoradID != "270000123" || tles[0].Line1.LineString != "" {
+		t.Errorf("expected element set without TLE lines, got %+v", tles[0])
+	}
+
+	var buf strings.Builder
+	if err := WriteOMMCSV(&buf, tles); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), ",270000123,") {
+		t.Errorf("9-digit catalog number missing from CSV:\n%s", buf.String())
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := map[string]Format{
+		sampleOMMJSON:                      FormatJSON,
+		sampleOMMKVN:                       FormatKVN,
+		"\n<?xml version=\"1.0\"?><ndm>":   FormatXML,
+		"OBJECT_NAME,OBJECT_ID,EPOCH\nISS": FormatCSV,
+		"ISS (ZARYA)\n1 25544U":            FormatTLE,
+	}
+	for data, want := range tests {
+		if got := DetectFormat([]byte(data)); got != want {
+			t.Errorf("DetectFormat(%.20q) = %s, want %s", data, got, want)
+		}
+	}
+	if got := FormatOf("elements/iss.xml"); got != FormatXML {
+		t.Errorf("FormatOf(iss.xml) = %s", got)
+	}
+	if got := FormatOf("iss.txt"); got != FormatTLE {
+		t.Errorf("FormatOf(iss.txt) = %s", got)
+	}
+}
@@ -0,0 +1,81 @@
+package tle
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// EarthMu is the WGS84 gravitational parameter of the Earth in km^3/s^2.
+	EarthMu = 398600.4418
+	// EarthRadius is the WGS84 equatorial radius of the Earth in km.
+	EarthRadius = 6378.137
+)
+
+// OrbitRegime is a coarse classification of an orbit by altitude and shape.
+type OrbitRegime string
+
+const (
+	RegimeLEO     OrbitRegime = "LEO"
+	RegimeMEO     OrbitRegime = "MEO"
+	RegimeGEO     OrbitRegime = "GEO"
+	RegimeHEO     OrbitRegime = "HEO"
+	RegimeMolniya OrbitRegime = "Molniya"
+)
+
+// OrbitParameters holds quantities derived from the mean elements of a TLE.
+// Altitudes are measured above the equatorial radius.
+type OrbitParameters struct {
+	SemiMajorAxis   float64     `json:"semi_major_axis_km"`
+	Period          float64     `json:"period_minutes"`
+	ApogeeAltitude  float64     `json:"apogee_altitude_km"`
+	PerigeeAltitude float64     `json:"perigee_altitude_km"`
+	SpecificEnergy  float64     `json:"specific_energy_km2_s2"`
+	Regime          OrbitRegime `json:"regime"`
+}
+
+// OrbitParameters derives the semi-major axis, period, apogee and perigee
+// altitudes, specific orbital energy and orbit regime from the mean motion
+// and eccentricity.
+func (t TLE) OrbitParameters() (OrbitParameters, error) {
+	e := t.Elements
+	if e.MeanMotion <= 0 {
+		return OrbitParameters{}, fmt.Errorf("invalid mean motion: %v", e.MeanMotion)
+	}
+	if e.Eccentricity < 0 || e.Eccentricity >= 1 {
+		return OrbitParameters{}, fmt.Errorf("invalid eccentricity: %v", e.Eccentricity)
+	}
+
+	n := e.MeanMotion * 2 * math.Pi / 86400 // rad/s
+	a := math.Cbrt(EarthMu / (n * n))
+
+	p := OrbitParameters{
+		SemiMajorAxis:   a,
+		Period:          1440 / e.MeanMotion,
+		ApogeeAltitude:  a*(1+e.Eccentricity) - EarthRadius,
+		PerigeeAltitude: a*(1-e.Eccentricity) - EarthRadius,
+		SpecificEnergy:  -EarthMu / (2 * a),
+	}
+	p.Regime = classifyOrbit(p, e)
+	return p, nil
+}
+
+// classifyOrbit assigns the orbit regime. Molniya orbits are half-day,
+// highly eccentric orbits near the 63.4° critical inclination.
+func classifyOrbit(p OrbitParameters, e Elements) OrbitRegime {
+	switch {
+	case e.Eccentricity >= 0.5 && p.Period > 600 && p.Period < 840 &&
+		e.Inclination > 60 && e.Inclination < 67:
+		return RegimeMolniya
+	case e.Eccentricity >= 0.25:
+		return RegimeHEO
+	case p.Period > 1300 && p.Period < 1600:
+		return RegimeGEO
+	case p.ApogeeAltitude < 2000:
+		return RegimeLEO
+	case p.ApogeeAltitude < 35786:
+		return RegimeMEO
+	default:
+		return RegimeHEO
+	}
+}
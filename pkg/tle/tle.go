@@ -1,13 +1,11 @@
 package tle
 
 import (
-	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
-
-	utils "github.com/Mohammed-Ashour/tlego/pkg/utils"
 )
 
 type TLELine1 struct {
@@ -47,10 +45,12 @@ ISS (ZARYA)
 2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537
 */
 type TLE struct {
-	Name    string
-	NoradID string
-	Line1   TLELine1
-	Line2   TLELine2
+	Name     string
+	NoradID  string
+	Line1    TLELine1
+	Line2    TLELine2
+	Elements Elements
+	Warnings []FieldError // format problems tolerated in Lenient mode
 }
 
 func (t TLE) String() string {
@@ -102,11 +102,11 @@ func ReadTLELine1(line string) (TLELine1, error) {
 		case "EpochDay":
 			tleLine1.EpochDay = value
 		case "FirstDerivative":
-			tleLine1.FirstDerivative = utils.ParseScientificNotation(value)
+			tleLine1.FirstDerivative = value
 		case "SecondDerivative":
-			tleLine1.SecondDerivative = utils.ParseScientificNotation(value)
+			tleLine1.SecondDerivative, err = exponentString(value, "second derivative")
 		case "Bstar":
-			tleLine1.Bstar = utils.ParseScientificNotation(value)
+			tleLine1.Bstar, err = exponentString(value, "bstar")
 		case "EphemerisType":
 			tleLine1.EphemerisType = value
 		case "ElementSetNumber":
@@ -114,9 +114,22 @@ func ReadTLELine1(line string) (TLELine1, error) {
 		case "Checksum":
 			tleLine1.Checksum = value
 		}
+		if err != nil {
+			return TLELine1{}, err
+		}
 	}
 
-	return tleLine1, err
+	return tleLine1, nil
+}
+
+// exponentString converts a field in the TLE's implied-decimal exponent
+// notation, e.g. "-11606-4", to standard notation.
+func exponentString(value, field string) (string, error) {
+	v, err := ParseExponent(value)
+	if err != nil {
+		return "", fmt.Errorf("%s %q: %w", field, value, err)
+	}
+	return strconv.FormatFloat(v, 'e', -1, 64), nil
 }
 
 func ReadTLELine2(line string) (TLELine2, error) {
@@ -169,12 +182,32 @@ func ReadTLELine2(line string) (TLELine2, error) {
 
 	return tleLine2, err
 }
+
+// ParseTLE parses a TLE set in Lenient mode.
 func ParseTLE(line1, line2, name string) (TLE, error) {
+	return ParseTLEMode(line1, line2, name, Lenient)
+}
+
+// ParseTLEMode parses a TLE set, validating it according to mode.
+func ParseTLEMode(line1, line2, name string, mode ParseMode) (TLE, error) {
 	tle := TLE{
 		Name: name,
 	}
 
+	problems := Validate(line1, line2)
+	if mode == Strict && len(problems) > 0 {
+		return TLE{}, &ValidationError{Name: name, Problems: problems}
+	}
+	tle.Warnings = problems
+
+	// The typed elements report malformed fields as errors, so parse them
+	// before the legacy string fields.
 	var err error
+	tle.Elements, err = ParseElements(line1, line2)
+	if err != nil {
+		return TLE{}, err
+	}
+
 	tle.Line1, err = ReadTLELine1(line1)
 	if err != nil {
 		return TLE{}, err
@@ -185,55 +218,36 @@ func ParseTLE(line1, line2, name string) (TLE, error) {
 		return TLE{}, err
 	}
 
+	tle.NoradID = strconv.Itoa(tle.Elements.CatalogNumber)
+
 	return tle, nil
 }
 
+// ReadTLEFile reads every TLE set in a file in Lenient mode.
 func ReadTLEFile(filePath string) ([]TLE, error) {
+	return ReadTLEFileMode(filePath, Lenient)
+}
+
+// ReadTLEFileMode reads every TLE set in a file, validating each according to mode.
+func ReadTLEFileMode(filePath string, mode ParseMode) ([]TLE, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	var tles []TLE
-	var currentTLE TLE
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		if strings.HasPrefix(line, "1 ") {
-			currentTLE.Line1, err = ReadTLELine1(line)
-			if err != nil {
-				return nil, err
-			}
-		} else if strings.HasPrefix(line, "2 ") {
-			components := strings.Fields(line)
-			currentTLE.NoradID = components[1]
-			currentTLE.Line2, err = ReadTLELine2(line)
-			if err != nil {
-				return nil, err
-			}
-
-			tles = append(tles, currentTLE)
-			currentTLE = TLE{}
-		} else {
-			currentTLE.Name = strings.TrimSpace(line)
-		}
+	return ReadTLEsMode(file, mode)
+}
 
+// GetTLETime returns the epoch of the TLE, keeping sub-second precision.
+func (t TLE) GetTLETime() (time.Time, error) {
+	epochYear, err := strconv.Atoi(t.Line1.EpochYear)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("epoch year %q: %w", t.Line1.EpochYear, err)
 	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	epochDay, err := strconv.ParseFloat(t.Line1.EpochDay, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("epoch day %q: %w", t.Line1.EpochDay, err)
 	}
-
-	return tles, nil
-}
-
-func (t TLE) GetTLETime() time.Time {
-	epochYear := utils.ParseInt(t.Line1.EpochYear)
-	year := 2000 + epochYear
-	day := utils.ParseFloat(t.Line1.EpochDay)
-	month, d, hour, min, sec := utils.Days2mdhms(year, day)
-	return time.Date(int(year), time.Month(month), d, hour, min, int(sec), 0, time.UTC)
+	return epochTime(epochYear, epochDay), nil
 }
@@ -1,9 +1,13 @@
 package tle
 
 import (
+	"errors"
+	"math"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	utils "github.com/Mohammed-Ashour/tlego/pkg/utils"
 )
@@ -126,3 +130,408 @@ func TestInvalidTLE(t *testing.T) {
 		})
 	}
 }
+
+func TestParseElements(t *testing.T) {
+	lines := strings.Split(sampleTLE, "\n")
+
+	tle, err := ParseTLE(lines[1], lines[2], lines[0])
+	if err != nil {
+		t.Fatalf("ParseTLE failed: %v", err)
+	}
+	e := tle.Elements
+
+	tests := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"Catalog Number", float64(e.CatalogNumber), 44744},
+		{"First Derivative", e.MeanMotionDot, 0.00031028},
+		{"Second Derivative", e.MeanMotionDDot, 0},
+		{"Bstar", e.Bstar, 0.20924e-2},
+		{"Element Set Number", float64(e.ElementSetNumber), 999},
+		{"Inclination", e.Inclination, 53.0542},
+		{"Right Ascension", e.RightAscension, 291.9231},
+		{"Eccentricity", e.Eccentricity, 0.0001291},
+		{"Argument Of Perigee", e.ArgumentOfPerigee, 91.3884},
+		{"Mean Anomaly", e.MeanAnomaly, 268.7253},
+		{"Mean Motion", e.MeanMotion, 15.06407194},
+		{"Revolution Number", float64(e.RevolutionNumber), 28556},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.expected) > 1e-12 {
+				t.Errorf("got %v, want %v", tt.got, tt.expected)
+			}
+		})
+	}
+
+	if e.IntlDesignator != "19074AH" {
+		t.Errorf("IntlDesignator: got %q, want %q", e.IntlDesignator, "19074AH")
+	}
+	if got := e.ObjectID(); got != "2019-074AH" {
+		t.Errorf("ObjectID: got %q, want %q", got, "2019-074AH")
+	}
+	if got := e.LaunchYear(); got != 2019 {
+		t.Errorf("LaunchYear: got %d, want 2019", got)
+	}
+	wantEpoch := time.Date(2025, time.January, 18, 4, 16, 17, 297608000, time.UTC)
+	if d := e.Epoch.Sub(wantEpoch); d > time.Millisecond || d < -time.Millisecond {
+		t.Errorf("Epoch: got %v, want %v", e.Epoch, wantEpoch)
+	}
+}
+
+func TestParseExponent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"-11606-4", -0.11606e-4},
+		{"20924-2", 0.20924e-2},
+		{"00000+0", 0},
+		{"+12345-3", 0.12345e-3},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseExponent(tt.in)
+			if err != nil {
+				t.Fatalf("ParseExponent(%q) error = %v", tt.in, err)
+			}
+			if math.Abs(got-tt.want) > 1e-15 {
+				t.Errorf("ParseExponent(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseExponent("1a3b4-2"); err == nil {
+		t.Error("expected error for malformed mantissa")
+	}
+}
+
+func TestParseTLEMode(t *testing.T) {
+	lines := strings.Split(sampleTLE, "\n")
+	badChecksum := lines[1][:68] + "5"
+	badInclination := lines[2][:8] + " 53.05x2" + lines[2][16:]
+
+	if _, err := ParseTLEMode(lines[1], lines[2], lines[0], Strict); err != nil {
+		t.Errorf("Strict parse of valid TLE failed: %v", err)
+	}
+
+	_, err := ParseTLEMode(badChecksum, lines[2], lines[0], Strict)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if len(verr.Problems) != 1 || verr.Problems[0].Field != "checksum" || verr.Problems[0].Line != 1 {
+		t.Errorf("unexpected problems: %v", verr.Problems)
+	}
+
+	_, err = ParseTLEMode(lines[1], badInclination, lines[0], Strict)
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	fields := map[string]bool{}
+	for _, p := range verr.Problems {
+		fields[p.Field] = true
+	}
+	if !fields["inclination"] || verr.Problems[0].Start != 9 || verr.Problems[0].End != 16 {
+		t.Errorf("expected inclination problem at cols 9-16, got %v", verr.Problems)
+	}
+
+	tle, err := ParseTLEMode(badChecksum, lines[2], lines[0], Lenient)
+	if err != nil {
+		t.Fatalf("Lenient parse failed: %v", err)
+	}
+	if len(tle.Warnings) != 1 {
+		t.Errorf("expected 1 warning, got %v", tle.Warnings)
+	}
+}
+
+func TestParseLenientGarbledExponent(t *testing.T) {
+	lines := strings.Split(sampleTLE, "\n")
+	for _, field := range [][2]int{{44, 52}, {53, 61}, {33, 43}} {
+		line1 := lines[1][:field[0]] + strings.Repeat(" ", field[1]-field[0]-1) + "x" + lines[1][field[1]:68]
+		line1 += strconv.Itoa(Checksum(line1))
+		if _, err := ParseTLEMode(line1, lines[2], lines[0], Lenient); err == nil {
+			t.Errorf("garbled cols %d-%d parsed without error", field[0]+1, field[1])
+		}
+		if field[0] != 33 {
+			if _, err := ReadTLELine1(line1); err == nil {
+				t.Errorf("ReadTLELine1 read garbled cols %d-%d without error", field[0]+1, field[1])
+			}
+		}
+	}
+}
+
+func TestValidateCatalogMismatch(t *testing.T) {
+	lines := strings.Split(sampleTLE, "\n")
+	line2 := "2 44745" + lines[2][7:68]
+	line2 += strconv.Itoa(Checksum(line2))
+
+	problems := Validate(lines[1], line2)
+	if len(problems) != 1 || problems[0].Field != "catalog number" {
+		t.Errorf("expected a single catalog number mismatch, got %v", problems)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	lines := strings.Split(sampleTLE, "\n")
+	input := "\r\n" +
+		"0 " + lines[0] + "\r\n" + lines[1] + "\r\n" + lines[2] + "\r\n" +
+		"\r\n" +
+		lines[1] + "   \n" + lines[2] + "\n"
+
+	dec := NewDecoderMode(strings.NewReader(input), Strict)
+	var names []string
+	for dec.Next() {
+		tle := dec.TLE()
+		if tle.NoradID != "44744" {
+			t.Errorf("NoradID: got %q, want 44744", tle.NoradID)
+		}
+		names = append(names, tle.Name)
+	}
+	if err := dec.Err(); err != nil {
+		t.Fatalf("Decoder error: %v", err)
+	}
+	if len(names) != 2 || names[0] != "STARLINK-1039" || names[1] != "" {
+		t.Errorf("unexpected names %q", names)
+	}
+
+	_, err := ReadTLEs(strings.NewReader(lines[0] + "\n" + lines[1] + "\n"))
+	if err == nil {
+		t.Error("expected error for truncated TLE set")
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	lines := strings.Split(sampleTLE, "\n")
+	iss := []string{
+		"1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927",
+		"2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537",
+	}
+
+	tests := []struct {
+		name         string
+		line1, line2 string
+		want1, want2 string
+	}{
+		{"Starlink", lines[1], lines[2], lines[1], lines[2]},
+		// The legacy "-0" zero exponent is re-emitted as "+0"
+		{"ISS", iss[0], iss[1], "1 25544U 98067A   08264.51782528 -.00002182  00000+0 -11606-4 0  2926", iss[1]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := ParseElements(tt.line1, tt.line2)
+			if err != nil {
+				t.Fatalf("ParseElements failed: %v", err)
+			}
+			line1, line2, err := e.Encode()
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if line1 != tt.want1 {
+				t.Errorf("line 1:\ngot  %q\nwant %q", line1, tt.want1)
+			}
+			if line2 != tt.want2 {
+				t.Errorf("line 2:\ngot  %q\nwant %q", line2, tt.want2)
+			}
+		})
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	lines := strings.Split(sampleTLE, "\n")
+	tle, err := ParseTLE(lines[1], lines[2], lines[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tle.Elements.Inclination = 97.5
+	tle.Elements.ElementSetNumber++
+
+	var buf strings.Builder
+	if err := NewEncoder(&buf).Encode(tle); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	got, err := ReadTLEsMode(strings.NewReader(buf.String()), Strict)
+	if err != nil {
+		t.Fatalf("re-reading encoded TLE failed: %v\n%s", err, buf.String())
+	}
+	if len(got) != 1 || got[0].Elements.Inclination != 97.5 || got[0].Elements.ElementSetNumber != 1000 {
+		t.Errorf("unexpected round trip result: %+v", got)
+	}
+}
+
+func TestFormatExponent(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, " 00000+0"},
+		{-0.11606e-4, "-11606-4"},
+		{0.20924e-2, " 20924-2"},
+		{0.999999e-3, " 10000-2"},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatExponent(tt.in)
+		if err != nil {
+			t.Fatalf("FormatExponent(%v) error = %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("FormatExponent(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogNumber(t *testing.T) {
+	tests := []struct {
+		text   string
+		number int
+	}{
+		{"25544", 25544},
+		{"00005", 5},
+		{"A0001", 100001},
+		{"H9999", 179999},
+		{"J0000", 180000},
+		{"Z9999", 339999},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseCatalogNumber(tt.text)
+			if err != nil || got != tt.number {
+				t.Errorf("ParseCatalogNumber(%q) = %d, %v, want %d", tt.text, got, err, tt.number)
+			}
+			text, err := FormatCatalogNumber(tt.number)
+			if err != nil || text != tt.text {
+				t.Errorf("FormatCatalogNumber(%d) = %q, %v, want %q", tt.number, text, err, tt.text)
+			}
+		})
+	}
+
+	if n, err := ParseCatalogNumber("270000123"); err != nil || n != 270000123 {
+		t.Errorf("9-digit catalog number: got %d, %v", n, err)
+	}
+	for _, bad := range []string{"", "I0001", "O1234", "A00x1", "1234567890", "-1"} {
+		if _, err := ParseCatalogNumber(bad); err == nil {
+			t.Errorf("ParseCatalogNumber(%q) expected error", bad)
+		}
+	}
+	if _, err := FormatCatalogNumber(340000); err == nil {
+		t.Error("FormatCatalogNumber(340000) expected error")
+	}
+}
+
+func TestAlpha5TLE(t *testing.T) {
+	lines := strings.Split(sampleTLE, "\n")
+	e, err := ParseElements(lines[1], lines[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.CatalogNumber = 100001
+
+	tle, err := NewTLE("SYNTHETIC", e)
+	if err != nil {
+		t.Fatalf("NewTLE failed: %v", err)
+	}
+	if tle.Line1.LineString[2:7] != "A0001" || tle.Line2.LineString[2:7] != "A0001" {
+		t.Errorf("expected Alpha-5 catalog columns, got\n%s", tle)
+	}
+	if tle.NoradID != "100001" || tle.Elements.CatalogNumber != 100001 {
+		t.Errorf("NoradID = %q, CatalogNumber = %d, want 100001", tle.NoradID, tle.Elements.CatalogNumber)
+	}
+}
+
+func TestGetTLETime(t *testing.T) {
+	tests := []struct {
+		name  string
+		line1 string
+		want  time.Time
+	}{
+		{
+			"ISS 2008",
+			"1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927",
+			time.Date(2008, time.September, 20, 12, 25, 40, 104192000, time.UTC),
+		},
+		{
+			"Vanguard 1 1999",
+			"1 00005U 58002B   99365.75000000  .00000023  00000-0  28098-4 0  4753",
+			time.Date(1999, time.December, 31, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			"Pivot 1957",
+			"1 00001U 57001A   57277.00000000  .00000000  00000-0  00000-0 0  0000",
+			time.Date(1957, time.October, 4, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line1, err := ReadTLELine1(tt.line1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := TLE{Line1: line1}.GetTLETime()
+			if err != nil {
+				t.Fatalf("GetTLETime() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTLETime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (TLE{}).GetTLETime(); err == nil {
+		t.Error("expected error for empty epoch")
+	}
+}
+
+func TestOrbitParameters(t *testing.T) {
+	tests := []struct {
+		name         string
+		elements     Elements
+		semiMajor    float64
+		period       float64
+		perigeeAlt   float64
+		expectRegime OrbitRegime
+	}{
+		{"ISS", Elements{MeanMotion: 15.72125391, Eccentricity: 0.0006703, Inclination: 51.6416}, 6730.96, 91.596, 348.31, RegimeLEO},
+		{"GPS", Elements{MeanMotion: 2.00563268, Eccentricity: 0.0092, Inclination: 55.1}, 26560.9, 717.98, 19938.4, RegimeMEO},
+		{"GEO", Elements{MeanMotion: 1.00271, Eccentricity: 0.0002, Inclination: 0.05}, 42164.2, 1436.1, 35777.6, RegimeGEO},
+		{"Molniya", Elements{MeanMotion: 2.00607, Eccentricity: 0.72, Inclination: 63.4}, 26556.5, 717.8, 1057.7, RegimeMolniya},
+		{"GTO", Elements{MeanMotion: 2.27, Eccentricity: 0.73, Inclination: 27}, 24456.0, 634.4, 225.0, RegimeHEO},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := TLE{Elements: tt.elements}.OrbitParameters()
+			if err != nil {
+				t.Fatalf("OrbitParameters() error = %v", err)
+			}
+			if math.Abs(p.SemiMajorAxis-tt.semiMajor) > 1 {
+				t.Errorf("SemiMajorAxis = %.2f, want %.2f", p.SemiMajorAxis, tt.semiMajor)
+			}
+			if math.Abs(p.Period-tt.period) > 0.1 {
+				t.Errorf("Period = %.3f, want %.3f", p.Period, tt.period)
+			}
+			if math.Abs(p.PerigeeAltitude-tt.perigeeAlt) > 1 {
+				t.Errorf("PerigeeAltitude = %.2f, want %.2f", p.PerigeeAltitude, tt.perigeeAlt)
+			}
+			if want := -EarthMu / (2 * p.SemiMajorAxis); p.SpecificEnergy != want {
+				t.Errorf("SpecificEnergy = %v, want %v", p.SpecificEnergy, want)
+			}
+			if p.Regime != tt.expectRegime {
+				t.Errorf("Regime = %s, want %s", p.Regime, tt.expectRegime)
+			}
+		})
+	}
+
+	if _, err := (TLE{}).OrbitParameters(); err == nil {
+		t.Error("expected error for zero mean motion")
+	}
+}
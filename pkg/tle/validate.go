@@ -0,0 +1,155 @@
+package tle
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	utils "github.com/Mohammed-Ashour/tlego/pkg/utils"
+)
+
+// ParseMode controls how ParseTLEMode and ReadTLEFileMode treat lines that
+// do not conform to the TLE format specification.
+type ParseMode int
+
+const (
+	// Lenient records format problems in TLE.Warnings and keeps parsing.
+	Lenient ParseMode = iota
+	// Strict rejects a TLE with a *ValidationError on the first bad set.
+	Strict
+)
+
+// FieldError describes a single problem found in a TLE line.
+// Columns are 1-based and inclusive, as in the format specification.
+type FieldError struct {
+	Line   int
+	Start  int
+	End    int
+	Field  string
+	Reason string
+}
+
+func (e FieldError) Error() string {
+	if e.Start == e.End {
+		return fmt.Sprintf("line %d col %d %s: %s", e.Line, e.Start, e.Field, e.Reason)
+	}
+	return fmt.Sprintf("line %d cols %d-%d %s: %s", e.Line, e.Start, e.End, e.Field, e.Reason)
+}
+
+// ValidationError is returned in Strict mode and lists every problem found.
+type ValidationError struct {
+	Name     string
+	Problems []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		msgs = append(msgs, p.Error())
+	}
+	name := e.Name
+	if name == "" {
+		name = "TLE"
+	}
+	return fmt.Sprintf("invalid %s: %s", name, strings.Join(msgs, "; "))
+}
+
+type fieldFormat struct {
+	start, end int // 1-based, inclusive
+	name       string
+	pattern    *regexp.Regexp
+}
+
+var (
+	blankOrDigits = regexp.MustCompile(`^ *[0-9]* *$`)
+	catalogFormat = regexp.MustCompile(`^([0-9 ]{5}|[A-HJ-NP-Z][0-9]{4})$`)
+	angleFormat   = regexp.MustCompile(`^ *[0-9]{1,3}\.[0-9]+ *$`)
+	exponentField = regexp.MustCompile(`^[ +-][0-9]{5}[+-][0-9]$`)
+)
+
+var line1Format = []fieldFormat{
+	{1, 1, "line number", regexp.MustCompile(`^1$`)},
+	{3, 7, "catalog number", catalogFormat},
+	{8, 8, "classification", regexp.MustCompile(`^[UCS ]$`)},
+	{10, 11, "launch year", blankOrDigits},
+	{12, 14, "launch number", blankOrDigits},
+	{15, 17, "launch piece", regexp.MustCompile(`^[A-Z ]*$`)},
+	{19, 20, "epoch year", regexp.MustCompile(`^[0-9]{2}$`)},
+	{21, 32, "epoch day", regexp.MustCompile(`^[0-9 ]{3}\.[0-9]{8}$`)},
+	{34, 43, "first derivative", regexp.MustCompile(`^[ +-]\.[0-9]{8}$`)},
+	{45, 52, "second derivative", exponentField},
+	{54, 61, "bstar", exponentField},
+	{63, 63, "ephemeris type", regexp.MustCompile(`^[0-9 ]$`)},
+	{65, 68, "element set number", blankOrDigits},
+	{69, 69, "checksum", regexp.MustCompile(`^[0-9]$`)},
+}
+
+var line2Format = []fieldFormat{
+	{1, 1, "line number", regexp.MustCompile(`^2$`)},
+	{3, 7, "catalog number", catalogFormat},
+	{9, 16, "inclination", angleFormat},
+	{18, 25, "right ascension", angleFormat},
+	{27, 33, "eccentricity", regexp.MustCompile(`^[0-9]{7}$`)},
+	{35, 42, "argument of perigee", angleFormat},
+	{44, 51, "mean anomaly", angleFormat},
+	{53, 63, "mean motion", regexp.MustCompile(`^ *[0-9]{1,2}\.[0-9]{8}$`)},
+	{64, 68, "revolution number", blankOrDigits},
+	{69, 69, "checksum", regexp.MustCompile(`^[0-9]$`)},
+}
+
+// Validate checks both TLE lines against the format specification and
+// returns every problem found. A nil result means the set is valid.
+func Validate(line1, line2 string) []FieldError {
+	var problems []FieldError
+	problems = append(problems, validateLine(1, line1, line1Format)...)
+	problems = append(problems, validateLine(2, line2, line2Format)...)
+
+	if len(line1) >= 7 && len(line2) >= 7 && line1[2:7] != line2[2:7] {
+		problems = append(problems, FieldError{
+			Line: 2, Start: 3, End: 7, Field: "catalog number",
+			Reason: fmt.Sprintf("%q does not match line 1 %q", line2[2:7], line1[2:7]),
+		})
+	}
+	return problems
+}
+
+func validateLine(lineNumber int, line string, formats []fieldFormat) []FieldError {
+	if len(line) != 69 {
+		return []FieldError{{
+			Line: lineNumber, Start: 1, End: 69, Field: "line",
+			Reason: fmt.Sprintf("length %d, want 69", len(line)),
+		}}
+	}
+
+	var problems []FieldError
+	for _, f := range formats {
+		value := line[f.start-1 : f.end]
+		if !f.pattern.MatchString(value) {
+			problems = append(problems, FieldError{
+				Line: lineNumber, Start: f.start, End: f.end, Field: f.name,
+				Reason: fmt.Sprintf("malformed value %q", value),
+			})
+		}
+	}
+
+	if !utils.VerifyChecksum(line) {
+		problems = append(problems, FieldError{
+			Line: lineNumber, Start: 69, End: 69, Field: "checksum",
+			Reason: fmt.Sprintf("got %c, want %d", line[68], Checksum(line)),
+		})
+	}
+	return problems
+}
+
+// Checksum computes the modulo-10 checksum of the first 68 columns of a TLE line.
+func Checksum(line string) int {
+	sum := 0
+	for i := 0; i < 68 && i < len(line); i++ {
+		if line[i] == '-' {
+			sum += 1
+		} else if line[i] >= '0' && line[i] <= '9' {
+			sum += int(line[i] - '0')
+		}
+	}
+	return sum % 10
+}
@@ -7,8 +7,8 @@ import (
 	"time"
 
 	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/satellite"
-	"github.com/Mohammed-Ashour/go-satellite-v2/pkg/tle"
 	"github.com/Mohammed-Ashour/tlego/pkg/templates"
+	"github.com/Mohammed-Ashour/tlego/pkg/tle"
 )
 
 // Point represents a satellite position with an associated timestamp.
@@ -21,10 +21,10 @@ type SatelliteData struct {
 }
 
 func CreateOrbitPoints(t tle.TLE, numPoints int) ([]Point, error) {
-	sat := satellite.NewSatelliteFromTLE(t, satellite.GravityWGS84)
+	sat := satellite.TLEToSat(t.Line1.LineString, t.Line2.LineString, satellite.GravityWGS84)
 
 	// Calculate orbital period from mean motion (revs per day)
-	meanMotion := tle.ParseFloat(t.Line2.MeanMotion)
+	meanMotion := t.Elements.MeanMotion
 	if meanMotion <= 0 {
 		return nil, fmt.Errorf("invalid mean motion: %v", meanMotion)
 	}
@@ -33,10 +33,7 @@ func CreateOrbitPoints(t tle.TLE, numPoints int) ([]Point, error) {
 	minutesPerOrbit := 24.0 * 60.0 / meanMotion
 
 	points := make([]Point, 0, numPoints)
-	epochTime, err := t.Time()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get epoch time: %v", err)
-	}
+	epochTime := t.Elements.Epoch
 
 	// Distribute points evenly across one complete orbit
 	for i := 0; i < numPoints; i++ {